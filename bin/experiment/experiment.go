@@ -62,16 +62,14 @@ import (
 
 	"github.com/figwood/litmus-go/pkg/clients"
 	"github.com/figwood/litmus-go/pkg/log"
-	"github.com/sirupsen/logrus"
+	"github.com/figwood/litmus-go/pkg/notify"
+	"github.com/figwood/litmus-go/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func init() {
-	// Log as JSON instead of the default ASCII formatter.
-	logrus.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:          true,
-		DisableSorting:         true,
-		DisableLevelTruncation: true,
-	})
+	// configure the log format and level, based on the LOG_FORMAT and LOG_LEVEL envs
+	log.Initialise()
 }
 
 func main() {
@@ -89,6 +87,12 @@ func main() {
 
 	log.Infof("Experiment Name: %v", *experimentName)
 
+	// start the trace of the experiment, the spans are exported only if the otlp endpoint is provided
+	tracing.Initialise("Experiment", attribute.String("chaos.experiment", *experimentName))
+	defer tracing.Shutdown()
+	// wait for the in-flight webhook notifications, e.g. the revert notifications of the helper pods
+	defer notify.Flush()
+
 	// invoke the corresponding experiment based on the (-name) flag
 	switch *experimentName {
 	case "container-kill":
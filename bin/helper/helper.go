@@ -20,16 +20,14 @@ import (
 
 	"github.com/figwood/litmus-go/pkg/clients"
 	"github.com/figwood/litmus-go/pkg/log"
-	"github.com/sirupsen/logrus"
+	"github.com/figwood/litmus-go/pkg/notify"
+	"github.com/figwood/litmus-go/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func init() {
-	// Log as JSON instead of the default ASCII formatter
-	logrus.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:          true,
-		DisableSorting:         true,
-		DisableLevelTruncation: true,
-	})
+	// configure the log format and level, based on the LOG_FORMAT and LOG_LEVEL envs
+	log.Initialise()
 }
 
 func main() {
@@ -47,6 +45,12 @@ func main() {
 
 	log.Infof("Helper Name: %v", *helperName)
 
+	// start the trace of the helper pod, it is the part of the experiment trace provided via TRACEPARENT env
+	tracing.Initialise("Helper", attribute.String("chaos.helper", *helperName))
+	defer tracing.Shutdown()
+	// wait for the in-flight webhook notifications, e.g. the revert notifications of the helper pods
+	defer notify.Flush()
+
 	// invoke the corresponding helper based on the the (-name) flag
 	switch *helperName {
 	case "container-kill":
@@ -3,9 +3,7 @@ package lib
 import (
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	experimentTypes "github.com/figwood/litmus-go/pkg/azure/instance-stop/types"
@@ -32,11 +30,11 @@ func PrepareAzureStop(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	// inject channel is used to transmit signal notifications
 	inject = make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to inject channel
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(abort)
 
 	// Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
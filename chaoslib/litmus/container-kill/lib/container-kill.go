@@ -275,6 +275,10 @@ func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, targets st
 		SetEnv("STATUS_CHECK_TIMEOUT", strconv.Itoa(experimentsDetails.Timeout)).
 		SetEnv("EXPERIMENT_NAME", experimentsDetails.ExperimentName).
 		SetEnv("INSTANCE_ID", experimentsDetails.InstanceID).
+		SetMetricsEnv().
+		SetTraceEnv().
+		SetLogEnv().
+		SetNotifyEnv().
 		SetEnvFromDownwardAPI("v1", "metadata.name")
 
 	return envDetails.ENV
@@ -283,6 +283,10 @@ func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, targets st
 		SetEnv("INSTANCE_ID", experimentsDetails.InstanceID).
 		SetEnv("SOCKET_PATH", experimentsDetails.SocketPath).
 		SetEnv("CONTAINER_RUNTIME", experimentsDetails.ContainerRuntime).
+		SetMetricsEnv().
+		SetTraceEnv().
+		SetLogEnv().
+		SetNotifyEnv().
 		SetEnvFromDownwardAPI("v1", "metadata.name")
 
 	return envDetails.ENV
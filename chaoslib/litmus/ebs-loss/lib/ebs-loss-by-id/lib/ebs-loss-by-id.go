@@ -3,9 +3,7 @@ package lib
 import (
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 
 	ebsloss "github.com/figwood/litmus-go/chaoslib/litmus/ebs-loss/lib"
 	"github.com/figwood/litmus-go/pkg/cerrors"
@@ -28,12 +26,12 @@ func PrepareEBSLossByID(experimentsDetails *experimentTypes.ExperimentDetails, c
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(abort)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
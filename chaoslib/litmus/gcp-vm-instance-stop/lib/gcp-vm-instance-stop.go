@@ -3,9 +3,7 @@ package lib
 import (
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/figwood/litmus-go/pkg/cerrors"
@@ -32,12 +30,12 @@ func PrepareVMStop(computeService *compute.Service, experimentsDetails *experime
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
 	// catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
 	// catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(abort)
 
 	// waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
@@ -50,7 +50,7 @@ func Helper(clients clients.ClientSets) {
 
 	// Initialise the chaos attributes
 	types.InitialiseChaosVariables(&chaosDetails)
-	chaosDetails.Phase = types.ChaosInjectPhase
+	types.SetChaosPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	// Initialise Chaos Result Parameters
 	types.SetResultAttributes(&resultDetails, chaosDetails)
@@ -279,6 +279,10 @@ func getPodEnv(experimentsDetails *experimentTypes.ExperimentDetails, targets, a
 		SetEnv("TARGET_SERVICE_PORT", strconv.Itoa(experimentsDetails.TargetServicePort)).
 		SetEnv("PROXY_PORT", strconv.Itoa(experimentsDetails.ProxyPort)).
 		SetEnv("TOXICITY", strconv.Itoa(experimentsDetails.Toxicity)).
+		SetMetricsEnv().
+		SetTraceEnv().
+		SetLogEnv().
+		SetNotifyEnv().
 		SetEnvFromDownwardAPI("v1", "metadata.name")
 
 	return envDetails.ENV
@@ -6,9 +6,7 @@ import (
 	"github.com/figwood/litmus-go/pkg/cerrors"
 	"github.com/palantir/stacktrace"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	clients "github.com/figwood/litmus-go/pkg/clients"
@@ -405,7 +403,7 @@ func abortPodAutoScalerChaos(appsUnderTest []experimentTypes.ApplicationUnderTes
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
+	types.NotifyAbort(signChan)
 
 	// waiting till the abort signal received
 	<-signChan
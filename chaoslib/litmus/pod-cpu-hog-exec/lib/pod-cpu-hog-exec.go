@@ -5,9 +5,7 @@ import (
 	"github.com/figwood/litmus-go/pkg/cerrors"
 	"github.com/palantir/stacktrace"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	clients "github.com/figwood/litmus-go/pkg/clients"
@@ -15,7 +13,6 @@ import (
 	experimentTypes "github.com/figwood/litmus-go/pkg/generic/pod-cpu-hog-exec/types"
 	"github.com/figwood/litmus-go/pkg/log"
 	"github.com/figwood/litmus-go/pkg/probe"
-	"github.com/figwood/litmus-go/pkg/result"
 	"github.com/figwood/litmus-go/pkg/types"
 	"github.com/figwood/litmus-go/pkg/utils/common"
 	litmusexec "github.com/figwood/litmus-go/pkg/utils/exec"
@@ -31,7 +28,7 @@ func PrepareCPUExecStress(experimentsDetails *experimentTypes.ExperimentDetails,
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(inject)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
@@ -112,7 +109,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(signChan)
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -173,13 +170,8 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 					if err := killStressCPUSerial(experimentsDetails, pod.Name, pod.Namespace, clients, chaosDetails); err != nil {
 						log.Errorf("Error in Kill stress after abortion, err: %v", err)
 					}
-					// updating the chaosresult after stopped
-					err := cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", pod.Name, pod.Namespace, experimentsDetails.TargetContainer), Reason: "experiment is aborted"}
-					failStep, errCode := cerrors.GetRootCauseAndErrorCode(err, string(chaosDetails.Phase))
-					types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, errCode)
-					if err := result.ChaosResult(chaosDetails, clients, resultDetails, "EOT"); err != nil {
-						log.Errorf("failed to update chaos result %s", err.Error())
-					}
+					// the verdict of the aborted experiment is recorded by the abort watcher, waiting for it before the exit
+					types.WaitForAbortRecord()
 					log.Info("[Chaos]: Revert Completed")
 					os.Exit(1)
 				case <-endTime:
@@ -211,7 +203,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(signChan)
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -268,13 +260,8 @@ loop:
 			if err := killStressCPUParallel(experimentsDetails, targetPodList, clients, chaosDetails); err != nil {
 				log.Errorf("Error in Kill stress after abortion, err: %v", err)
 			}
-			// updating the chaosresult after stopped
-			err := cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Reason: "experiment is aborted"}
-			failStep, errCode := cerrors.GetRootCauseAndErrorCode(err, string(chaosDetails.Phase))
-			types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, errCode)
-			if err := result.ChaosResult(chaosDetails, clients, resultDetails, "EOT"); err != nil {
-				log.Errorf("failed to update chaos result %s", err.Error())
-			}
+			// the verdict of the aborted experiment is recorded by the abort watcher, waiting for it before the exit
+			types.WaitForAbortRecord()
 			log.Info("[Chaos]: Revert Completed")
 			os.Exit(1)
 		case <-endTime:
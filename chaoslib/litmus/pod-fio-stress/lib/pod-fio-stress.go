@@ -3,13 +3,10 @@ package lib
 import (
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/figwood/litmus-go/pkg/cerrors"
-	"github.com/figwood/litmus-go/pkg/result"
 	"github.com/palantir/stacktrace"
 
 	clients "github.com/figwood/litmus-go/pkg/clients"
@@ -141,7 +138,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 		// signChan channel is used to transmit signal notifications.
 		signChan := make(chan os.Signal, 1)
 		// Catch and relay certain signal(s) to signChan channel.
-		signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+		types.NotifyAbort(signChan)
 
 	loop:
 		for {
@@ -163,12 +160,8 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 				if err := killStressSerial(experimentsDetails.TargetContainer, pod.Name, pod.Namespace, experimentsDetails.ChaosKillCmd, clients); err != nil {
 					log.Errorf("Error in Kill stress after abortion, err: %v", err)
 				}
-				err := cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", pod.Name, pod.Namespace, experimentsDetails.TargetContainer), Reason: "experiment is aborted"}
-				failStep, errCode := cerrors.GetRootCauseAndErrorCode(err, string(chaosDetails.Phase))
-				types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, errCode)
-				if err := result.ChaosResult(chaosDetails, clients, resultDetails, "EOT"); err != nil {
-					log.Errorf("failed to update chaos result %s", err.Error())
-				}
+				// the verdict of the aborted experiment is recorded by the abort watcher, waiting for it before the exit
+				types.WaitForAbortRecord()
 				log.Info("[Chaos]: Revert Completed")
 				os.Exit(1)
 			case <-endTime:
@@ -223,7 +216,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(signChan)
 loop:
 	for {
 		endTime = time.After(timeDelay)
@@ -244,12 +237,8 @@ loop:
 			if err := killStressParallel(experimentsDetails.TargetContainer, targetPodList, experimentsDetails.ChaosKillCmd, clients); err != nil {
 				log.Errorf("Error in Kill stress after abortion, err: %v", err)
 			}
-			err := cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Reason: "experiment is aborted"}
-			failStep, errCode := cerrors.GetRootCauseAndErrorCode(err, string(chaosDetails.Phase))
-			types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, errCode)
-			if err := result.ChaosResult(chaosDetails, clients, resultDetails, "EOT"); err != nil {
-				log.Errorf("failed to update chaos result %s", err.Error())
-			}
+			// the verdict of the aborted experiment is recorded by the abort watcher, waiting for it before the exit
+			types.WaitForAbortRecord()
 			log.Info("[Chaos]: Revert Completed")
 			os.Exit(1)
 		case <-endTime:
@@ -3,10 +3,8 @@ package lib
 import (
 	"fmt"
 	"os"
-	"os/signal"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/figwood/litmus-go/pkg/cerrors"
@@ -17,7 +15,6 @@ import (
 	experimentTypes "github.com/figwood/litmus-go/pkg/generic/pod-memory-hog-exec/types"
 	"github.com/figwood/litmus-go/pkg/log"
 	"github.com/figwood/litmus-go/pkg/probe"
-	"github.com/figwood/litmus-go/pkg/result"
 	"github.com/figwood/litmus-go/pkg/types"
 	"github.com/figwood/litmus-go/pkg/utils/common"
 	litmusexec "github.com/figwood/litmus-go/pkg/utils/exec"
@@ -33,7 +30,7 @@ func PrepareMemoryExecStress(experimentsDetails *experimentTypes.ExperimentDetai
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(inject)
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
@@ -121,7 +118,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(signChan)
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -179,13 +176,8 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 					if err := killStressMemorySerial(experimentsDetails.TargetContainer, pod.Name, pod.Namespace, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
 						log.Errorf("Error in Kill stress after abortion, err: %v", err)
 					}
-					// updating the chaosresult after stopped
-					err := cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Target: fmt.Sprintf("{podName: %s, namespace: %s, container: %s}", pod.Name, pod.Namespace, experimentsDetails.TargetContainer), Reason: "experiment is aborted"}
-					failStep, errCode := cerrors.GetRootCauseAndErrorCode(err, string(chaosDetails.Phase))
-					types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, errCode)
-					if err := result.ChaosResult(chaosDetails, clients, resultDetails, "EOT"); err != nil {
-						log.Errorf("failed to update chaos result %s", err.Error())
-					}
+					// the verdict of the aborted experiment is recorded by the abort watcher, waiting for it before the exit
+					types.WaitForAbortRecord()
 					log.Info("[Chaos]: Revert Completed")
 					os.Exit(1)
 				case <-endTime:
@@ -216,7 +208,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(signChan)
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -273,13 +265,8 @@ loop:
 			if err := killStressMemoryParallel(experimentsDetails.TargetContainer, targetPodList, experimentsDetails.ChaosKillCmd, clients, chaosDetails); err != nil {
 				log.Errorf("Error in Kill stress after abortion, err: %v", err)
 			}
-			// updating the chaosresult after stopped
-			err := cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Reason: "experiment is aborted"}
-			failStep, errCode := cerrors.GetRootCauseAndErrorCode(err, string(chaosDetails.Phase))
-			types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, errCode)
-			if err := result.ChaosResult(chaosDetails, clients, resultDetails, "EOT"); err != nil {
-				log.Errorf("failed to update chaos result %s", err.Error())
-			}
+			// the verdict of the aborted experiment is recorded by the abort watcher, waiting for it before the exit
+			types.WaitForAbortRecord()
 			log.Info("[Chaos]: Revert Completed")
 			os.Exit(1)
 		case <-endTime:
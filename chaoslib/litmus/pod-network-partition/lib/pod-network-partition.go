@@ -4,9 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/figwood/litmus-go/pkg/cerrors"
@@ -16,7 +14,6 @@ import (
 	experimentTypes "github.com/figwood/litmus-go/pkg/generic/pod-network-partition/types"
 	"github.com/figwood/litmus-go/pkg/log"
 	"github.com/figwood/litmus-go/pkg/probe"
-	"github.com/figwood/litmus-go/pkg/result"
 	"github.com/figwood/litmus-go/pkg/types"
 	"github.com/figwood/litmus-go/pkg/utils/common"
 	"github.com/figwood/litmus-go/pkg/utils/retry"
@@ -37,12 +34,12 @@ func PrepareAndInjectChaos(experimentsDetails *experimentTypes.ExperimentDetails
 	// inject channel is used to transmit signal notifications.
 	inject = make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(inject)
 
 	// abort channel is used to transmit signal notifications.
 	abort = make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(abort)
 
 	// validate the appLabels
 	if chaosDetails.AppDetail == nil {
@@ -244,11 +241,8 @@ func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients
 		}
 		retry--
 	}
-	// updating the chaosresult after stopped
-	err := cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Reason: "experiment is aborted"}
-	failStep, errCode := cerrors.GetRootCauseAndErrorCode(err, string(chaosDetails.Phase))
-	types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, errCode)
-	result.ChaosResult(chaosDetails, clients, resultDetails, "EOT")
+	// the verdict of the aborted experiment is recorded by the abort watcher, waiting for it before the exit
+	types.WaitForAbortRecord()
 	log.Info("Chaos Revert Completed")
 	os.Exit(0)
 }
@@ -6,9 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/figwood/litmus-go/pkg/cerrors"
@@ -19,7 +17,6 @@ import (
 	"github.com/figwood/litmus-go/pkg/events"
 	"github.com/figwood/litmus-go/pkg/log"
 	"github.com/figwood/litmus-go/pkg/probe"
-	"github.com/figwood/litmus-go/pkg/result"
 	experimentTypes "github.com/figwood/litmus-go/pkg/spring-boot/spring-boot-chaos/types"
 	"github.com/figwood/litmus-go/pkg/types"
 	"github.com/figwood/litmus-go/pkg/utils/common"
@@ -223,7 +220,7 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(signChan)
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -274,10 +271,8 @@ func injectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 					} else {
 						common.SetTargets(pod.Name, "reverted", "pod", chaosDetails)
 					}
-					// updating the chaosresult after stopped
-					failStep := "Chaos injection stopped!"
-					types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, cerrors.ErrorTypeExperimentAborted)
-					result.ChaosResult(chaosDetails, clients, resultDetails, "EOT")
+					// the verdict of the aborted experiment is recorded by the abort watcher, waiting for it before the exit
+					types.WaitForAbortRecord()
 					log.Info("[Chaos]: Revert Completed")
 					os.Exit(1)
 				case <-endTime:
@@ -311,7 +306,7 @@ func injectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(signChan)
 
 	var endTime <-chan time.Time
 	timeDelay := time.Duration(experimentsDetails.ChaosDuration) * time.Second
@@ -364,10 +359,8 @@ loop:
 					common.SetTargets(pod.Name, "reverted", "pod", chaosDetails)
 				}
 			}
-			// updating the chaosresult after stopped
-			failStep := "Chaos injection stopped!"
-			types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, cerrors.ErrorTypeExperimentAborted)
-			result.ChaosResult(chaosDetails, clients, resultDetails, "EOT")
+			// the verdict of the aborted experiment is recorded by the abort watcher, waiting for it before the exit
+			types.WaitForAbortRecord()
 			log.Info("[Chaos]: Revert Completed")
 			os.Exit(1)
 		case <-endTime:
@@ -120,7 +120,7 @@ func AzureInstanceStop(clients clients.ClientSets) {
 		log.Info("[Status]: Azure instance(s) is in running state (pre-chaos)")
 	}
 
-	chaosDetails.Phase = types.ChaosInjectPhase
+	types.SetChaosPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	if err = litmusLIB.PrepareAzureStop(&experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		log.Errorf("Chaos injection failed: %v", err)
@@ -131,7 +131,7 @@ func AzureInstanceStop(clients clients.ClientSets) {
 	log.Info("[Confirmation]: Azure instance stop chaos has been injected successfully")
 	resultDetails.Verdict = v1alpha1.ResultVerdictPassed
 
-	chaosDetails.Phase = types.PostChaosPhase
+	types.SetChaosPhase(&chaosDetails, types.PostChaosPhase)
 
 	//Verify the azure instance is running (post chaos)
 	if chaosDetails.DefaultHealthCheck {
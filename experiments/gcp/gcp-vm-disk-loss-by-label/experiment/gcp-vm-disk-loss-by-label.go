@@ -115,7 +115,7 @@ func GCPVMDiskLossByLabel(clients clients.ClientSets) {
 
 	log.Info("[Status]: Disk volumes are attached to the VM instances (pre-chaos)")
 
-	chaosDetails.Phase = types.ChaosInjectPhase
+	types.SetChaosPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	if err := litmusLIB.PrepareDiskVolumeLossByLabel(computeService, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		log.Errorf("Chaos injection failed, err: %v", err)
@@ -126,7 +126,7 @@ func GCPVMDiskLossByLabel(clients clients.ClientSets) {
 	log.Infof("[Confirmation]: %v chaos has been injected successfully", experimentsDetails.ExperimentName)
 	resultDetails.Verdict = v1alpha1.ResultVerdictPassed
 
-	chaosDetails.Phase = types.PostChaosPhase
+	types.SetChaosPhase(&chaosDetails, types.PostChaosPhase)
 
 	// Checking disk volume attachment post-chaos
 	for i := range experimentsDetails.TargetDiskVolumeNamesList {
@@ -117,7 +117,7 @@ func VMInstanceStop(clients clients.ClientSets) {
 		log.Info("[Status]: VM instance is in running state (pre-chaos)")
 	}
 
-	chaosDetails.Phase = types.ChaosInjectPhase
+	types.SetChaosPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	if err := litmusLIB.PrepareVMStop(computeService, &experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		log.Errorf("Chaos injection failed, err: %v", err)
@@ -128,7 +128,7 @@ func VMInstanceStop(clients clients.ClientSets) {
 	log.Infof("[Confirmation]: %v chaos has been injected successfully", experimentsDetails.ExperimentName)
 	resultDetails.Verdict = v1alpha1.ResultVerdictPassed
 
-	chaosDetails.Phase = types.PostChaosPhase
+	types.SetChaosPhase(&chaosDetails, types.PostChaosPhase)
 
 	//Verify the GCP VM instance is in RUNNING status (post-chaos)
 	if chaosDetails.DefaultHealthCheck {
@@ -123,7 +123,7 @@ func KafkaBrokerPodFailure(clients clients.ClientSets) {
 
 	kafka.DisplayKafkaBroker(&experimentsDetails)
 
-	chaosDetails.Phase = types.ChaosInjectPhase
+	types.SetChaosPhase(&chaosDetails, types.ChaosInjectPhase)
 
 	if err := kafkaPodDelete.PreparePodDelete(&experimentsDetails, clients, &resultDetails, &eventsDetails, &chaosDetails); err != nil {
 		log.Errorf("Chaos injection failed, err: %v", err)
@@ -134,7 +134,7 @@ func KafkaBrokerPodFailure(clients clients.ClientSets) {
 	log.Infof("[Confirmation]: %v chaos has been injected successfully", experimentsDetails.ExperimentName)
 	resultDetails.Verdict = v1alpha1.ResultVerdictPassed
 
-	chaosDetails.Phase = types.PostChaosPhase
+	types.SetChaosPhase(&chaosDetails, types.PostChaosPhase)
 
 	// POST-CHAOS KAFKA CLUSTER HEALTH CHECK
 	if chaosDetails.DefaultHealthCheck {
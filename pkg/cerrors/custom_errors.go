@@ -15,6 +15,7 @@ const (
 	ErrorTypeStatusChecks      ErrorType = "STATUS_CHECKS_ERROR"
 	ErrorTypeTargetSelection   ErrorType = "TARGET_SELECTION_ERROR"
 	ErrorTypeExperimentAborted ErrorType = "EXPERIMENT_ABORTED"
+	ErrorTypeProbeHalted       ErrorType = "PROBE_HALTED"
 	ErrorTypeHelper            ErrorType = "HELPER_ERROR"
 	ErrorTypeHelperPodFailed   ErrorType = "HELPER_POD_FAILED_ERROR"
 	ErrorTypeContainerRuntime  ErrorType = "CONTAINER_RUNTIME_ERROR"
@@ -28,6 +29,20 @@ const (
 	FailureTypeHttpProbe       ErrorType = "HTTP_PROBE_FAILURE"
 	ErrorTypePromProbe         ErrorType = "PROM_PROBE_ERROR"
 	FailureTypePromProbe       ErrorType = "PROM_PROBE_FAILURE"
+	ErrorTypeGRPCProbe         ErrorType = "GRPC_PROBE_ERROR"
+	FailureTypeGRPCProbe       ErrorType = "GRPC_PROBE_FAILURE"
+	ErrorTypeSocketProbe       ErrorType = "SOCKET_PROBE_ERROR"
+	FailureTypeSocketProbe     ErrorType = "SOCKET_PROBE_FAILURE"
+	ErrorTypeDNSProbe          ErrorType = "DNS_PROBE_ERROR"
+	FailureTypeDNSProbe        ErrorType = "DNS_PROBE_FAILURE"
+	ErrorTypeLogProbe          ErrorType = "LOG_PROBE_ERROR"
+	FailureTypeLogProbe        ErrorType = "LOG_PROBE_FAILURE"
+	ErrorTypeEventProbe        ErrorType = "EVENT_PROBE_ERROR"
+	FailureTypeEventProbe      ErrorType = "EVENT_PROBE_FAILURE"
+	ErrorTypeRestartProbe      ErrorType = "RESTART_PROBE_ERROR"
+	FailureTypeRestartProbe    ErrorType = "RESTART_PROBE_FAILURE"
+	ErrorTypeSQLProbe          ErrorType = "SQL_PROBE_ERROR"
+	FailureTypeSQLProbe        ErrorType = "SQL_PROBE_FAILURE"
 	ErrorTypeTimeout           ErrorType = "TIMEOUT"
 	FailureTypeProbeTimeout    ErrorType = "PROBE_TIMEOUT"
 )
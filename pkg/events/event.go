@@ -5,6 +5,7 @@ import (
 	"time"
 
 	clients "github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/notify"
 	"github.com/figwood/litmus-go/pkg/types"
 	apiv1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -43,6 +44,7 @@ func CreateEvents(eventsDetails *types.EventDetails, clients clients.ClientSets,
 // GenerateEvents update the events and increase the count by 1, if already present
 // else it will create a new event
 func GenerateEvents(eventsDetails *types.EventDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, kind string) error {
+	sendNotification(eventsDetails, kind)
 
 	switch kind {
 	case "ChaosResult":
@@ -74,3 +76,34 @@ func GenerateEvents(eventsDetails *types.EventDetails, clients clients.ClientSet
 	}
 	return nil
 }
+
+// sendNotification sends the webhook notification for the lifecycle events of the experiment
+// i.e, the start of test, chaos injection, abort and the verdict at the end of test
+func sendNotification(eventsDetails *types.EventDetails, kind string) {
+	notification := notify.Notification{
+		Reason:  eventsDetails.Reason,
+		Message: eventsDetails.Message,
+		Type:    eventsDetails.Type,
+	}
+	switch kind {
+	case "ChaosResult":
+		switch eventsDetails.Reason {
+		case types.AwaitedVerdict:
+			notification.Event = notify.StartOfTest
+		case types.AbortVerdict:
+			notification.Event, notification.Verdict = notify.Abort, "Stopped"
+		case types.Summary:
+			notification.Event = notify.EndOfTest
+		default:
+			notification.Event, notification.Verdict = notify.EndOfTest, eventsDetails.Reason
+		}
+	case "ChaosEngine":
+		if eventsDetails.Reason != types.ChaosInject {
+			return
+		}
+		notification.Event = notify.Injection
+	default:
+		return
+	}
+	notify.Send(notification)
+}
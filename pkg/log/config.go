@@ -0,0 +1,107 @@
+package log
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	logrus "github.com/sirupsen/logrus"
+)
+
+// correlation fields, these are added to all the log lines of the run
+const (
+	ExperimentField = "experiment"
+	EngineField     = "engine"
+	ChaosUIDField   = "chaosUID"
+	RunIDField      = "runID"
+	PhaseField      = "phase"
+	TargetPodField  = "targetPod"
+)
+
+// runFields contains the correlation fields of the run
+type runFields struct {
+	mutex  sync.RWMutex
+	fields map[string]string
+}
+
+var correlation = &runFields{fields: map[string]string{}}
+
+var initialise sync.Once
+
+// Initialise configures the format and level of the logs, based on the LOG_FORMAT and LOG_LEVEL envs
+// LOG_FORMAT can be text (default) or json and LOG_LEVEL can be any of the logrus levels, info by default
+// the correlation fields and the redaction of the secrets are added to all the log lines, via the logrus hook
+func Initialise() {
+	initialise.Do(func() {
+		switch strings.ToLower(strings.TrimSpace(os.Getenv("LOG_FORMAT"))) {
+		case "json":
+			logrus.SetFormatter(&logrus.JSONFormatter{})
+		default:
+			logrus.SetFormatter(&logrus.TextFormatter{
+				FullTimestamp:          true,
+				DisableSorting:         true,
+				DisableLevelTruncation: true,
+			})
+		}
+		logrus.AddHook(&contextHook{})
+
+		if value := strings.TrimSpace(os.Getenv("LOG_LEVEL")); value != "" {
+			level, err := logrus.ParseLevel(value)
+			if err != nil {
+				Warnf("unsupported log level '%s', using info", value)
+				return
+			}
+			logrus.SetLevel(level)
+		}
+	})
+}
+
+// SetField sets the correlation field, it is added to all the subsequent log lines
+// the field is removed if the value is empty
+func SetField(key, value string) {
+	correlation.mutex.Lock()
+	defer correlation.mutex.Unlock()
+	if value == "" {
+		delete(correlation.fields, key)
+		return
+	}
+	correlation.fields[key] = value
+}
+
+// Field returns the value of the correlation field
+func Field(key string) string {
+	correlation.mutex.RLock()
+	defer correlation.mutex.RUnlock()
+	return correlation.fields[key]
+}
+
+// contextHook adds the correlation fields to the log entry and redacts the secrets from it
+type contextHook struct{}
+
+// Levels returns the levels for which the hook is fired
+func (h *contextHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire updates the log entry before it is formatted
+// the fields provided by the caller take precedence over the correlation fields
+func (h *contextHook) Fire(entry *logrus.Entry) error {
+	correlation.mutex.RLock()
+	for key, value := range correlation.fields {
+		if _, ok := entry.Data[key]; !ok {
+			entry.Data[key] = value
+		}
+	}
+	correlation.mutex.RUnlock()
+
+	entry.Message = Redact(entry.Message)
+	for key, value := range entry.Data {
+		switch v := value.(type) {
+		case string:
+			entry.Data[key] = Redact(v)
+		case error:
+			entry.Data[key] = Redact(v.Error())
+		}
+	}
+	return nil
+}
@@ -1,5 +1,7 @@
 package math
 
+import gomath "math"
+
 // Maximum calculates the maximum value among two integers
 func Maximum(a int, b int) int {
 	if a > b {
@@ -20,3 +22,14 @@ func Minimum(a int, b int) int {
 func Adjustment(a int, b int) int {
 	return (a * b / 100)
 }
+
+// Percentile calculates the given percentile of the values using the nearest-rank method
+// the values are expected to be sorted in the ascending order
+func Percentile(values []float64, percentile float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	// the epsilon avoids the floating point error, e.g. 99.9/100*1000 = 999.0000000000001 which ranks 1000 instead of 999
+	rank := int(gomath.Ceil(percentile*float64(len(values))/100 - 1e-9))
+	return values[Maximum(0, Minimum(rank, len(values))-1)]
+}
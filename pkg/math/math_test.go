@@ -0,0 +1,47 @@
+package math
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentile(t *testing.T) {
+	hundred := make([]float64, 100)
+	for i := range hundred {
+		hundred[i] = float64(i + 1)
+	}
+	thousand := make([]float64, 1000)
+	for i := range thousand {
+		thousand[i] = float64(i + 1)
+	}
+
+	tests := []struct {
+		name       string
+		values     []float64
+		percentile float64
+		want       float64
+	}{
+		{name: "empty sample", values: nil, percentile: 95, want: 0},
+		{name: "single sample p0", values: []float64{42}, percentile: 0, want: 42},
+		{name: "single sample p50", values: []float64{42}, percentile: 50, want: 42},
+		{name: "single sample p100", values: []float64{42}, percentile: 100, want: 42},
+		{name: "p0 is minimum", values: hundred, percentile: 0, want: 1},
+		{name: "p100 is maximum", values: hundred, percentile: 100, want: 100},
+		{name: "p50 of even sample", values: []float64{1, 2, 3, 4}, percentile: 50, want: 2},
+		{name: "p50 of odd sample", values: []float64{1, 2, 3, 4, 5}, percentile: 50, want: 3},
+		{name: "p95 of hundred", values: hundred, percentile: 95, want: 95},
+		{name: "p99 of hundred", values: hundred, percentile: 99, want: 99},
+		{name: "rank rounded up", values: []float64{10, 20, 30}, percentile: 34, want: 20},
+		{name: "exact rank", values: []float64{10, 20, 30}, percentile: 33.3333333333, want: 10},
+		{name: "floating point error", values: thousand, percentile: 99.9, want: 999},
+		{name: "fractional percentile", values: thousand, percentile: 99.95, want: 1000},
+		{name: "negative percentile", values: hundred, percentile: -5, want: 1},
+		{name: "percentile above 100", values: hundred, percentile: 150, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Percentile(tt.values, tt.percentile))
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/log"
+)
+
+// contentType is the content type of the prometheus text format
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// verdicts are the possible verdicts of the experiment
+var verdicts = []string{"Awaited", "Pass", "Fail", "Stopped", "Error"}
+
+var (
+	defaultRegistry = &registry{}
+
+	injectionStartTime = defaultRegistry.newFamily("litmuschaos_injection_start_timestamp_seconds",
+		"Unix time at which the chaos is injected into the target", gaugeType, "target", "kind")
+	injectionEndTime = defaultRegistry.newFamily("litmuschaos_injection_end_timestamp_seconds",
+		"Unix time at which the chaos is reverted from the target", gaugeType, "target", "kind")
+	probeEvaluations = defaultRegistry.newFamily("litmuschaos_probe_evaluations_total",
+		"Number of the probe evaluations, by the result of the evaluation", counterType, "probe", "type", "mode", "result")
+	probeDuration = defaultRegistry.newFamily("litmuschaos_probe_duration_seconds",
+		"Duration of the probe evaluations, including the retries", histogramType, "probe", "type", "mode")
+	revertFailures = defaultRegistry.newFamily("litmuschaos_revert_failures_total",
+		"Number of the failures to revert the chaos", counterType)
+	experimentVerdict = defaultRegistry.newFamily("litmuschaos_experiment_verdict",
+		"Verdict of the experiment, the current verdict is set to 1", gaugeType, "verdict")
+)
+
+// config contains the metrics configuration of the run
+type config struct {
+	mutex          sync.Mutex
+	initialised    bool
+	address        string
+	pushGatewayURL string
+	pushJob        string
+	instance       string
+}
+
+var metricsConfig = &config{}
+
+// Initialise configures the metrics of the run, it is called once for the experiment and helper pods
+// it serves the metrics on /metrics, if METRICS_ADDRESS env is provided
+// and pushes the metrics to the pushgateway, if PUSHGATEWAY_URL env is provided
+func Initialise(experimentName, engineName, namespace string) {
+	metricsConfig.mutex.Lock()
+	defer metricsConfig.mutex.Unlock()
+
+	defaultRegistry.setConstLabels(
+		label{name: "experiment", value: experimentName},
+		label{name: "engine", value: engineName},
+		label{name: "namespace", value: namespace},
+	)
+	if metricsConfig.initialised {
+		return
+	}
+	metricsConfig.initialised = true
+	metricsConfig.address = strings.TrimSpace(os.Getenv("METRICS_ADDRESS"))
+	metricsConfig.pushGatewayURL = strings.TrimSuffix(strings.TrimSpace(os.Getenv("PUSHGATEWAY_URL")), "/")
+	metricsConfig.pushJob = getenv("PUSHGATEWAY_JOB", "litmuschaos")
+	metricsConfig.instance = getenv("POD_NAME", "")
+	if metricsConfig.instance == "" {
+		metricsConfig.instance, _ = os.Hostname()
+	}
+
+	if metricsConfig.address != "" {
+		go serve(metricsConfig.address)
+	}
+}
+
+// RecordInjectionStart records the time at which the chaos is injected into the target
+func RecordInjectionStart(target, kind string) {
+	defaultRegistry.set(injectionStartTime, float64(time.Now().Unix()), target, kind)
+}
+
+// RecordInjectionEnd records the time at which the chaos is reverted from the target
+func RecordInjectionEnd(target, kind string) {
+	defaultRegistry.set(injectionEndTime, float64(time.Now().Unix()), target, kind)
+}
+
+// RecordTargetStatus records the injection and revert time of the target, based on the chaos status of the target
+func RecordTargetStatus(target, kind, status string) {
+	switch strings.ToLower(status) {
+	case "injected", "detached":
+		RecordInjectionStart(target, kind)
+	case "reverted", "re-attached":
+		RecordInjectionEnd(target, kind)
+	}
+}
+
+// RecordProbe records the result and duration of the probe evaluation
+func RecordProbe(name, probeType, mode string, passed bool, duration time.Duration) {
+	result := "success"
+	if !passed {
+		result = "failure"
+	}
+	defaultRegistry.add(probeEvaluations, 1, name, probeType, mode, result)
+	defaultRegistry.observe(probeDuration, duration.Seconds(), name, probeType, mode)
+}
+
+// RecordRevertFailure records the failure to revert the chaos
+func RecordRevertFailure() {
+	defaultRegistry.add(revertFailures, 1)
+}
+
+// SetVerdict sets the verdict of the experiment
+func SetVerdict(verdict string) {
+	for _, v := range verdicts {
+		value := 0.0
+		if v == verdict {
+			value = 1
+		}
+		defaultRegistry.set(experimentVerdict, value, v)
+	}
+}
+
+// Push pushes all the metrics to the pushgateway, if configured
+// the metrics of the pod are grouped by the job and instance, the latest push replaces the previous one
+func Push() error {
+	metricsConfig.mutex.Lock()
+	pushGatewayURL, job, instance := metricsConfig.pushGatewayURL, metricsConfig.pushJob, metricsConfig.instance
+	metricsConfig.mutex.Unlock()
+	if pushGatewayURL == "" {
+		return nil
+	}
+
+	var body bytes.Buffer
+	if err := defaultRegistry.write(&body); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to encode the metrics: %v", err)}
+	}
+	endpoint := pushGatewayURL + "/metrics" + getGroupingPath("job", job)
+	if instance != "" {
+		endpoint += getGroupingPath("instance", instance)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, &body)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{url: %s}", pushGatewayURL), Reason: fmt.Sprintf("failed to create the push request: %v", err)}
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{url: %s}", pushGatewayURL), Reason: fmt.Sprintf("failed to push the metrics: %v", err)}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{url: %s}", pushGatewayURL), Reason: fmt.Sprintf("failed to push the metrics, status code: %d", resp.StatusCode)}
+	}
+	return nil
+}
+
+// getGroupingPath returns the path of the grouping label of the pushgateway
+// the values containing the slash are base64 encoded, as the pushgateway doesn't accept these otherwise
+func getGroupingPath(name, value string) string {
+	if strings.Contains(value, "/") {
+		return fmt.Sprintf("/%s@base64/%s", name, base64.URLEncoding.EncodeToString([]byte(value)))
+	}
+	return fmt.Sprintf("/%s/%s", name, url.PathEscape(value))
+}
+
+// serve serves the metrics on /metrics endpoint
+func serve(address string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		if err := defaultRegistry.write(w); err != nil {
+			log.Errorf("[Metrics]: Unable to write the metrics, err: %v", err)
+		}
+	})
+	log.Infof("[Metrics]: Serving the metrics on %s/metrics", address)
+	server := &http.Server{Addr: address, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	if err := server.ListenAndServe(); err != nil {
+		log.Errorf("[Metrics]: Unable to serve the metrics, err: %v", err)
+	}
+}
+
+// getenv returns the value of the env, it returns the default value if the env is not set
+func getenv(key, defaultValue string) string {
+	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+		return value
+	}
+	return defaultValue
+}
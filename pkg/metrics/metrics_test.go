@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPush(t *testing.T) {
+	type request struct {
+		method      string
+		path        string
+		contentType string
+		body        string
+	}
+	var requests []request
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{method: r.Method, path: r.URL.EscapedPath(), contentType: r.Header.Get("Content-Type"), body: string(body)})
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	previous := metricsConfig
+	defer func() {
+		metricsConfig = previous
+	}()
+	defaultRegistry.setConstLabels(label{name: "experiment", value: "pod-delete"}, label{name: "engine", value: "nginx-chaos"}, label{name: "namespace", value: "litmus"})
+	SetVerdict("Pass")
+
+	tests := []struct {
+		name       string
+		job        string
+		instance   string
+		statusCode int
+		wantPath   string
+		wantErr    bool
+	}{
+		{name: "job and instance", job: "litmuschaos", instance: "pod-delete-abcde", statusCode: http.StatusOK, wantPath: "/metrics/job/litmuschaos/instance/pod-delete-abcde"},
+		{name: "without instance", job: "litmuschaos", statusCode: http.StatusAccepted, wantPath: "/metrics/job/litmuschaos"},
+		{name: "escaped job", job: "chaos run", instance: "pod-delete-abcde", statusCode: http.StatusOK, wantPath: "/metrics/job/chaos%20run/instance/pod-delete-abcde"},
+		// the values with the slash are base64 encoded, e.g. litmus/pod-delete
+		{name: "base64 encoded job", job: "litmus/pod-delete", instance: "pod-delete-abcde", statusCode: http.StatusOK, wantPath: "/metrics/job@base64/bGl0bXVzL3BvZC1kZWxldGU=/instance/pod-delete-abcde"},
+		{name: "failed push", job: "litmuschaos", instance: "pod-delete-abcde", statusCode: http.StatusBadRequest, wantPath: "/metrics/job/litmuschaos/instance/pod-delete-abcde", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests, statusCode = nil, tt.statusCode
+			metricsConfig = &config{initialised: true, pushGatewayURL: server.URL, pushJob: tt.job, instance: tt.instance}
+
+			err := Push()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.Len(t, requests, 1)
+			assert.Equal(t, http.MethodPut, requests[0].method)
+			assert.Equal(t, tt.wantPath, requests[0].path)
+			assert.Equal(t, contentType, requests[0].contentType)
+			assert.Contains(t, requests[0].body, "# TYPE litmuschaos_experiment_verdict gauge\n")
+			assert.Contains(t, requests[0].body, `litmuschaos_experiment_verdict{experiment="pod-delete",engine="nginx-chaos",namespace="litmus",verdict="Pass"} 1`)
+		})
+	}
+
+	// the metrics are not pushed, if the pushgateway is not configured
+	requests = nil
+	metricsConfig = &config{initialised: true, pushJob: "litmuschaos"}
+	assert.NoError(t, Push())
+	assert.Empty(t, requests)
+}
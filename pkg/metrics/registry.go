@@ -0,0 +1,190 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// metricType is the type of the metric family, as defined by the prometheus text format
+type metricType string
+
+const (
+	counterType   metricType = "counter"
+	gaugeType     metricType = "gauge"
+	histogramType metricType = "histogram"
+)
+
+// defaultBuckets are the buckets of the histograms, in seconds
+var defaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// family contains all the series of the metric
+type family struct {
+	name    string
+	help    string
+	kind    metricType
+	labels  []string
+	buckets []float64
+	series  map[string]*series
+}
+
+// series contains the value of the metric for the given label values
+type series struct {
+	labelValues []string
+	value       float64
+	counts      []uint64
+	sum         float64
+	count       uint64
+}
+
+// registry contains all the metric families of the run
+// the constant labels are added to all the series
+type registry struct {
+	mutex       sync.Mutex
+	constLabels []label
+	families    []*family
+}
+
+// label contains the name and value of the label
+type label struct {
+	name  string
+	value string
+}
+
+// newFamily registers the metric family inside the registry
+func (r *registry) newFamily(name, help string, kind metricType, labels ...string) *family {
+	f := &family{name: name, help: help, kind: kind, labels: labels, series: map[string]*series{}}
+	if kind == histogramType {
+		f.buckets = defaultBuckets
+	}
+	r.families = append(r.families, f)
+	return f
+}
+
+// setConstLabels sets the labels which are added to all the series
+func (r *registry) setConstLabels(labels ...label) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.constLabels = labels
+}
+
+// getSeries returns the series of the family for the given label values, it creates the series if not present
+// it should be called with the lock held
+func (f *family) getSeries(labelValues []string) *series {
+	key := strings.Join(labelValues, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: labelValues}
+		if f.kind == histogramType {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// set sets the value of the gauge
+func (r *registry) set(f *family, value float64, labelValues ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	f.getSeries(labelValues).value = value
+}
+
+// add increments the value of the counter
+func (r *registry) add(f *family, value float64, labelValues ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	f.getSeries(labelValues).value += value
+}
+
+// observe adds the observation to the histogram
+func (r *registry) observe(f *family, value float64, labelValues ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	s := f.getSeries(labelValues)
+	for index, bucket := range f.buckets {
+		if value <= bucket {
+			s.counts[index]++
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+// write writes all the metrics in the prometheus text format
+func (r *registry) write(w io.Writer) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var out strings.Builder
+	for _, f := range r.families {
+		if len(f.series) == 0 {
+			continue
+		}
+		fmt.Fprintf(&out, "# HELP %s %s\n# TYPE %s %s\n", f.name, escape(f.help, false), f.name, f.kind)
+
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			s := f.series[key]
+			labels := append([]label{}, r.constLabels...)
+			for index, name := range f.labels {
+				labels = append(labels, label{name: name, value: s.labelValues[index]})
+			}
+			if f.kind != histogramType {
+				fmt.Fprintf(&out, "%s%s %s\n", f.name, formatLabels(labels), formatValue(s.value))
+				continue
+			}
+			for index, bucket := range f.buckets {
+				fmt.Fprintf(&out, "%s_bucket%s %d\n", f.name, formatLabels(append(labels, label{name: "le", value: formatValue(bucket)})), s.counts[index])
+			}
+			fmt.Fprintf(&out, "%s_bucket%s %d\n", f.name, formatLabels(append(labels, label{name: "le", value: "+Inf"})), s.count)
+			fmt.Fprintf(&out, "%s_sum%s %s\n", f.name, formatLabels(labels), formatValue(s.sum))
+			fmt.Fprintf(&out, "%s_count%s %d\n", f.name, formatLabels(labels), s.count)
+		}
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// formatLabels returns the labels in the prometheus text format
+func formatLabels(labels []label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, l := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", l.name, escape(l.value, true)))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// formatValue returns the value in the prometheus text format
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// escape escapes the help text and label values, the quotes are escaped only inside the label values
+func escape(value string, quote bool) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	if quote {
+		value = strings.ReplaceAll(value, `"`, `\"`)
+	}
+	return value
+}
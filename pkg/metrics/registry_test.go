@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"bytes"
+	"flag"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update regenerates the golden files, e.g. go test ./pkg/metrics/ -update
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestRegistryWrite(t *testing.T) {
+	scenarios := []struct {
+		name   string
+		record func(r *registry)
+	}{
+		{
+			name: "counter",
+			record: func(r *registry) {
+				evaluations := r.newFamily("litmuschaos_probe_evaluations_total", "Number of the probe evaluations", counterType, "probe", "result")
+				r.add(evaluations, 1, "http-health", "success")
+				r.add(evaluations, 2, "http-health", "success")
+				r.add(evaluations, 1, "cmd-check", "failure")
+				failures := r.newFamily("litmuschaos_revert_failures_total", "Number of the failures to revert the chaos", counterType)
+				r.add(failures, 1)
+				// the families without series are skipped
+				r.newFamily("litmuschaos_unused_total", "Unused family", counterType)
+			},
+		},
+		{
+			name: "gauge",
+			record: func(r *registry) {
+				verdict := r.newFamily("litmuschaos_experiment_verdict", "Verdict of the experiment", gaugeType, "verdict")
+				r.set(verdict, 0, "Awaited")
+				r.set(verdict, 1, "Pass")
+				r.set(verdict, 0, "Awaited")
+				startTime := r.newFamily("litmuschaos_injection_start_timestamp_seconds", "Unix time at which the chaos is injected", gaugeType, "target", "kind")
+				r.set(startTime, 1709287200, "nginx-7d8f9c-abcde", "pod")
+				r.set(startTime, 0.5, "worker-1", "node")
+			},
+		},
+		{
+			name: "histogram",
+			record: func(r *registry) {
+				duration := r.newFamily("litmuschaos_probe_duration_seconds", "Duration of the probe evaluations", histogramType, "probe")
+				r.observe(duration, 0.25, "http-health")
+				r.observe(duration, 2, "http-health")
+				// the observations above the largest bucket are counted only in the +Inf bucket
+				r.observe(duration, 75, "http-health")
+				r.observe(duration, 0.005, "cmd-check")
+			},
+		},
+		{
+			name: "escaped",
+			record: func(r *registry) {
+				failures := r.newFamily("litmuschaos_probe_failures_total", "Number of the probe failures,\nby the \"reason\" and path C:\\probes", counterType, "reason", "path")
+				r.add(failures, 1, `probe "http-health" failed`+"\n"+"status: 500", `C:\probes\http`)
+			},
+		},
+		{
+			name: "const-labels",
+			record: func(r *registry) {
+				r.setConstLabels(label{name: "experiment", value: "pod-delete"}, label{name: "engine", value: "nginx-chaos"}, label{name: "namespace", value: `litmus "test"`})
+				failures := r.newFamily("litmuschaos_revert_failures_total", "Number of the failures to revert the chaos", counterType)
+				r.add(failures, 1)
+				verdict := r.newFamily("litmuschaos_experiment_verdict", "Verdict of the experiment", gaugeType, "verdict")
+				r.set(verdict, 1, "Stopped")
+				duration := r.newFamily("litmuschaos_probe_duration_seconds", "Duration of the probe evaluations", histogramType, "probe")
+				r.observe(duration, 1.5, "http-health")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			r := &registry{}
+			scenario.record(r)
+			var got bytes.Buffer
+			require.NoError(t, r.write(&got))
+
+			golden := filepath.Join("testdata", scenario.name+".prom")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, got.Bytes(), 0644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), got.String())
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{value: 0, want: "0"},
+		{value: 1709287200, want: "1.7092872e+09"},
+		{value: 0.005, want: "0.005"},
+		{value: math.Inf(1), want: "+Inf"},
+		{value: math.Inf(-1), want: "-Inf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatValue(tt.value))
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/utils/retry"
+)
+
+// Event is the lifecycle event of the experiment
+type Event string
+
+const (
+	// StartOfTest is sent once the experiment is started
+	StartOfTest Event = "SOT"
+	// Injection is sent once the chaos is injected
+	Injection Event = "Injection"
+	// Revert is sent once the chaos is reverted from the target
+	Revert Event = "Revert"
+	// Abort is sent once the experiment is aborted
+	Abort Event = "Abort"
+	// EndOfTest is sent once the verdict of the experiment is available
+	EndOfTest Event = "EOT"
+)
+
+const (
+	// requestTimeout is the timeout of each webhook request
+	requestTimeout = 10 * time.Second
+	// flushTimeout is the maximum duration to wait for the in-flight notifications
+	flushTimeout = 30 * time.Second
+)
+
+// Notification contains the details of the lifecycle event, it is the data of the payload templates
+type Notification struct {
+	Event      Event
+	Experiment string
+	Engine     string
+	Namespace  string
+	ChaosUID   string
+	RunID      string
+	Target     string
+	TargetKind string
+	Verdict    string
+	Reason     string
+	Message    string
+	// Type is the type of the kubernetes event, i.e, Normal or Warning
+	Type      string
+	Timestamp time.Time
+}
+
+// webhook contains the url and payload format of the webhook
+type webhook struct {
+	url    string
+	format string
+}
+
+// notifier contains the webhooks and the run details of the experiment or helper pod
+type notifier struct {
+	mutex       sync.Mutex
+	initialised bool
+	webhooks    []webhook
+	events      map[Event]bool
+	run         Notification
+	inflight    sync.WaitGroup
+}
+
+var runNotifier = &notifier{}
+
+// Initialise configures the webhooks of the run, it is called once for the experiment and helper pods
+// the webhooks are provided via NOTIFY_WEBHOOK_URLS env, as comma separated [generic|slack|teams=]<url>
+// the events can be filtered via NOTIFY_EVENTS env, e.g. SOT,Abort,EOT, all the events are sent by default
+// the payload of the generic webhooks can be overridden via NOTIFY_TEMPLATE env, it should be a go template rendering the json
+func Initialise(experimentName, engineName, namespace, chaosUID, runID string) {
+	runNotifier.mutex.Lock()
+	defer runNotifier.mutex.Unlock()
+
+	runNotifier.run = Notification{
+		Experiment: experimentName,
+		Engine:     engineName,
+		Namespace:  namespace,
+		ChaosUID:   chaosUID,
+		RunID:      runID,
+	}
+	if runNotifier.initialised {
+		return
+	}
+	runNotifier.initialised = true
+	runNotifier.webhooks = parseWebhooks(os.Getenv("NOTIFY_WEBHOOK_URLS"))
+	runNotifier.events = parseEvents(os.Getenv("NOTIFY_EVENTS"))
+	if value := strings.TrimSpace(os.Getenv("NOTIFY_TEMPLATE")); value != "" {
+		if err := setGenericTemplate(value); err != nil {
+			log.Errorf("[Notify]: Unable to parse the NOTIFY_TEMPLATE, using the default template, err: %v", err)
+		}
+	}
+	for _, w := range runNotifier.webhooks {
+		// the webhook urls contain the tokens, e.g. slack and teams webhooks
+		log.RegisterSecret(w.url)
+	}
+}
+
+// Send sends the notification to all the webhooks, in the background
+// it waits for the delivery of the terminal events, i.e, Abort and EOT, as the pod exits afterwards
+// the failures are only logged, these never fail the experiment
+func Send(notification Notification) {
+	runNotifier.mutex.Lock()
+	webhooks, events, run := runNotifier.webhooks, runNotifier.events, runNotifier.run
+	runNotifier.mutex.Unlock()
+	if len(webhooks) == 0 || (len(events) != 0 && !events[notification.Event]) {
+		return
+	}
+
+	notification.Experiment, notification.Engine, notification.Namespace = run.Experiment, run.Engine, run.Namespace
+	notification.ChaosUID, notification.RunID = run.ChaosUID, run.RunID
+	if notification.Timestamp.IsZero() {
+		notification.Timestamp = time.Now().UTC()
+	}
+	for _, w := range webhooks {
+		runNotifier.inflight.Add(1)
+		go func(w webhook) {
+			defer runNotifier.inflight.Done()
+			if err := w.deliver(notification); err != nil {
+				log.Errorf("[Notify]: Unable to send the %v notification to the %v webhook, err: %v", notification.Event, w.format, err)
+			}
+		}(w)
+	}
+
+	if notification.Event == Abort || notification.Event == EndOfTest {
+		Flush()
+	}
+}
+
+// RecordTargetStatus sends the revert notification once the chaos is reverted from the target
+func RecordTargetStatus(target, kind, chaosStatus string) {
+	switch strings.ToLower(chaosStatus) {
+	case "reverted", "re-attached":
+		Send(Notification{
+			Event:      Revert,
+			Target:     target,
+			TargetKind: kind,
+			Reason:     chaosStatus,
+			Message:    fmt.Sprintf("chaos has been reverted from the %s %s", strings.ToLower(kind), target),
+			Type:       "Normal",
+		})
+	}
+}
+
+// Flush waits for the in-flight notifications, it doesn't wait more than the flush timeout
+func Flush() {
+	done := make(chan struct{})
+	go func() {
+		runNotifier.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(flushTimeout):
+		log.Warnf("[Notify]: Timed out while waiting for the notifications after %v", flushTimeout)
+	}
+}
+
+// deliver posts the payload to the webhook, it retries the network failures, 429 and 5xx responses
+func (w webhook) deliver(notification Notification) error {
+	payload, err := render(w.format, notification)
+	if err != nil {
+		return err
+	}
+
+	return retry.
+		Times(3).
+		Wait(time.Second).
+		Backoff(2, 10*time.Second).
+		Jitter(0.2).
+		Try(func(attempt uint) error {
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+			if err != nil {
+				return retry.Permanent(cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to create the webhook request: %v", err)})
+			}
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to send the webhook request: %v", err)}
+			}
+			defer resp.Body.Close()
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+			switch {
+			case resp.StatusCode/100 == 2:
+				return nil
+			case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode/100 == 5:
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("webhook responded with status code: %d", resp.StatusCode)}
+			}
+			return retry.Permanent(cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("webhook responded with status code: %d", resp.StatusCode)})
+		})
+}
+
+// parseWebhooks parses the comma separated webhooks, the format is derived from the host if not provided
+func parseWebhooks(value string) []webhook {
+	var webhooks []webhook
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		w := webhook{url: entry}
+		if format, rawURL, ok := strings.Cut(entry, "="); ok && isSupportedFormat(strings.ToLower(format)) {
+			w.format, w.url = strings.ToLower(format), strings.TrimSpace(rawURL)
+		}
+		u, err := url.Parse(w.url)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			log.Errorf("[Notify]: Skipping the invalid webhook url: %v", w.url)
+			continue
+		}
+		if w.format == "" {
+			w.format = getFormatFromHost(u.Host)
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks
+}
+
+// getFormatFromHost returns the payload format based on the host of the webhook
+func getFormatFromHost(host string) string {
+	switch {
+	case host == "hooks.slack.com":
+		return slackFormat
+	case strings.HasSuffix(host, ".webhook.office.com"), strings.HasSuffix(host, ".logic.azure.com"):
+		return teamsFormat
+	}
+	return genericFormat
+}
+
+// parseEvents parses the comma separated events, it returns nil if no filter is provided
+func parseEvents(value string) map[Event]bool {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	events := map[Event]bool{}
+	for _, e := range strings.Split(value, ",") {
+		for _, event := range []Event{StartOfTest, Injection, Revert, Abort, EndOfTest} {
+			if strings.EqualFold(strings.TrimSpace(e), string(event)) {
+				events[event] = true
+			}
+		}
+	}
+	return events
+}
@@ -0,0 +1,165 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"text/template"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+)
+
+// supported payload formats of the webhooks
+const (
+	genericFormat = "generic"
+	slackFormat   = "slack"
+	teamsFormat   = "teams"
+)
+
+// genericTemplate is the default payload of the generic webhooks
+const genericTemplate = `{
+  "event": {{json .Event}},
+  "experiment": {{json .Experiment}},
+  "engine": {{json .Engine}},
+  "namespace": {{json .Namespace}},
+  "chaosUID": {{json .ChaosUID}},
+  "runID": {{json .RunID}},
+  "target": {{json .Target}},
+  "targetKind": {{json .TargetKind}},
+  "verdict": {{json .Verdict}},
+  "reason": {{json .Reason}},
+  "message": {{json .Message}},
+  "type": {{json .Type}},
+  "timestamp": {{json .Timestamp}}
+}`
+
+// slackTemplate is the payload of the slack incoming webhooks
+const slackTemplate = `{
+  "text": {{json .Title}},
+  "attachments": [{
+    "color": {{json (printf "#%s" .Color)}},
+    "text": {{json .Message}},
+    "fields": [
+      {"title": "Experiment", "value": {{json .Experiment}}, "short": true},
+      {"title": "Namespace", "value": {{json .Namespace}}, "short": true}{{if .Engine}},
+      {"title": "Engine", "value": {{json .Engine}}, "short": true}{{end}}{{if .Target}},
+      {"title": "Target", "value": {{json (printf "%s/%s" .TargetKind .Target)}}, "short": true}{{end}}{{if .Verdict}},
+      {"title": "Verdict", "value": {{json .Verdict}}, "short": true}{{end}},
+      {"title": "Run ID", "value": {{json .RunID}}, "short": true}
+    ],
+    "ts": {{.Timestamp.Unix}}
+  }]
+}`
+
+// teamsTemplate is the payload of the microsoft teams incoming webhooks
+const teamsTemplate = `{
+  "@type": "MessageCard",
+  "@context": "https://schema.org/extensions",
+  "themeColor": {{json .Color}},
+  "summary": {{json .Title}},
+  "sections": [{
+    "activityTitle": {{json .Title}},
+    "text": {{json .Message}},
+    "facts": [
+      {"name": "Experiment", "value": {{json .Experiment}}},
+      {"name": "Namespace", "value": {{json .Namespace}}}{{if .Engine}},
+      {"name": "Engine", "value": {{json .Engine}}}{{end}}{{if .Target}},
+      {"name": "Target", "value": {{json (printf "%s/%s" .TargetKind .Target)}}}{{end}}{{if .Verdict}},
+      {"name": "Verdict", "value": {{json .Verdict}}}{{end}},
+      {"name": "Run ID", "value": {{json .RunID}}},
+      {"name": "Time", "value": {{json .Timestamp}}}
+    ]
+  }]
+}`
+
+// templateFuncs contains the functions available inside the payload templates
+var templateFuncs = template.FuncMap{
+	"json": func(value interface{}) (string, error) {
+		out, err := json.Marshal(value)
+		return string(out), err
+	},
+}
+
+var templates = struct {
+	mutex   sync.RWMutex
+	formats map[string]*template.Template
+}{
+	formats: map[string]*template.Template{
+		genericFormat: template.Must(template.New(genericFormat).Funcs(templateFuncs).Parse(genericTemplate)),
+		slackFormat:   template.Must(template.New(slackFormat).Funcs(templateFuncs).Parse(slackTemplate)),
+		teamsFormat:   template.Must(template.New(teamsFormat).Funcs(templateFuncs).Parse(teamsTemplate)),
+	},
+}
+
+// isSupportedFormat checks whether the payload format is supported
+func isSupportedFormat(format string) bool {
+	templates.mutex.RLock()
+	defer templates.mutex.RUnlock()
+	_, ok := templates.formats[format]
+	return ok
+}
+
+// setGenericTemplate overrides the payload template of the generic webhooks
+func setGenericTemplate(value string) error {
+	tmpl, err := template.New(genericFormat).Funcs(templateFuncs).Parse(value)
+	if err != nil {
+		return err
+	}
+	templates.mutex.Lock()
+	defer templates.mutex.Unlock()
+	templates.formats[genericFormat] = tmpl
+	return nil
+}
+
+// render renders the payload of the notification in the given format
+func render(format string, notification Notification) ([]byte, error) {
+	templates.mutex.RLock()
+	tmpl, ok := templates.formats[format]
+	templates.mutex.RUnlock()
+	if !ok {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unsupported webhook format: %s", format)}
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, notification); err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to render the %s payload: %v", format, err)}
+	}
+	if !json.Valid(out.Bytes()) {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("rendered %s payload is not a valid json", format)}
+	}
+	return out.Bytes(), nil
+}
+
+// Title returns the title of the notification
+func (n Notification) Title() string {
+	switch n.Event {
+	case StartOfTest:
+		return fmt.Sprintf("Chaos experiment %s started", n.Experiment)
+	case Injection:
+		return fmt.Sprintf("Chaos injected by %s experiment", n.Experiment)
+	case Revert:
+		return fmt.Sprintf("Chaos reverted from %s/%s by %s experiment", n.TargetKind, n.Target, n.Experiment)
+	case Abort:
+		return fmt.Sprintf("Chaos experiment %s aborted", n.Experiment)
+	case EndOfTest:
+		if n.Verdict != "" {
+			return fmt.Sprintf("Chaos experiment %s completed with verdict %s", n.Experiment, n.Verdict)
+		}
+		return fmt.Sprintf("Chaos experiment %s completed", n.Experiment)
+	}
+	return fmt.Sprintf("Chaos experiment %s: %s", n.Experiment, n.Event)
+}
+
+// Color returns the hex color of the notification, based on the event and verdict
+func (n Notification) Color() string {
+	switch {
+	case n.Event == Abort, n.Type == "Warning":
+		return "E01E5A"
+	case n.Event == EndOfTest && n.Verdict == "Pass":
+		return "2EB886"
+	case n.Event == Injection:
+		return "ECB22E"
+	}
+	return "439FE0"
+}
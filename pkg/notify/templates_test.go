@@ -0,0 +1,213 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verdictNotification contains the quotes and newlines, which should be escaped inside the payloads
+var verdictNotification = Notification{
+	Event:      EndOfTest,
+	Experiment: "pod-delete",
+	Engine:     "nginx-chaos",
+	Namespace:  "litmus",
+	ChaosUID:   "4f9c1e2a",
+	RunID:      "abcde",
+	Target:     "nginx-7d8f9c-abcde",
+	TargetKind: "Pod",
+	Verdict:    `Fail "probe" breached`,
+	Reason:     "Summary",
+	Message:    "pod-delete experiment has been Failed\nprobe \"http-health\" failed:\t500",
+	Type:       "Warning",
+	Timestamp:  time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC),
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: genericFormat,
+			want: `{
+				"event": "EOT",
+				"experiment": "pod-delete",
+				"engine": "nginx-chaos",
+				"namespace": "litmus",
+				"chaosUID": "4f9c1e2a",
+				"runID": "abcde",
+				"target": "nginx-7d8f9c-abcde",
+				"targetKind": "Pod",
+				"verdict": "Fail \"probe\" breached",
+				"reason": "Summary",
+				"message": "pod-delete experiment has been Failed\nprobe \"http-health\" failed:\t500",
+				"type": "Warning",
+				"timestamp": "2024-03-01T10:00:00Z"
+			}`,
+		},
+		{
+			format: slackFormat,
+			want: `{
+				"text": "Chaos experiment pod-delete completed with verdict Fail \"probe\" breached",
+				"attachments": [{
+					"color": "#E01E5A",
+					"text": "pod-delete experiment has been Failed\nprobe \"http-health\" failed:\t500",
+					"fields": [
+						{"title": "Experiment", "value": "pod-delete", "short": true},
+						{"title": "Namespace", "value": "litmus", "short": true},
+						{"title": "Engine", "value": "nginx-chaos", "short": true},
+						{"title": "Target", "value": "Pod/nginx-7d8f9c-abcde", "short": true},
+						{"title": "Verdict", "value": "Fail \"probe\" breached", "short": true},
+						{"title": "Run ID", "value": "abcde", "short": true}
+					],
+					"ts": 1709287200
+				}]
+			}`,
+		},
+		{
+			format: teamsFormat,
+			want: `{
+				"@type": "MessageCard",
+				"@context": "https://schema.org/extensions",
+				"themeColor": "E01E5A",
+				"summary": "Chaos experiment pod-delete completed with verdict Fail \"probe\" breached",
+				"sections": [{
+					"activityTitle": "Chaos experiment pod-delete completed with verdict Fail \"probe\" breached",
+					"text": "pod-delete experiment has been Failed\nprobe \"http-health\" failed:\t500",
+					"facts": [
+						{"name": "Experiment", "value": "pod-delete"},
+						{"name": "Namespace", "value": "litmus"},
+						{"name": "Engine", "value": "nginx-chaos"},
+						{"name": "Target", "value": "Pod/nginx-7d8f9c-abcde"},
+						{"name": "Verdict", "value": "Fail \"probe\" breached"},
+						{"name": "Run ID", "value": "abcde"},
+						{"name": "Time", "value": "2024-03-01T10:00:00Z"}
+					]
+				}]
+			}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			payload, err := render(tt.format, verdictNotification)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(payload))
+		})
+	}
+}
+
+func TestRenderOptionalFields(t *testing.T) {
+	// the optional engine, target and verdict fields are skipped in the slack and teams payloads
+	notification := Notification{Event: StartOfTest, Experiment: "pod-delete", Namespace: "litmus", RunID: "abcde", Timestamp: verdictNotification.Timestamp}
+	for _, format := range []string{slackFormat, teamsFormat} {
+		t.Run(format, func(t *testing.T) {
+			payload, err := render(format, notification)
+			require.NoError(t, err)
+			assert.NotContains(t, string(payload), "Engine")
+			assert.NotContains(t, string(payload), "Target")
+			assert.NotContains(t, string(payload), "Verdict")
+			assert.Contains(t, string(payload), "Chaos experiment pod-delete started")
+		})
+	}
+}
+
+func TestRenderUnsupportedFormat(t *testing.T) {
+	_, err := render("discord", verdictNotification)
+	assert.Error(t, err)
+}
+
+func TestSetGenericTemplate(t *testing.T) {
+	defer func() {
+		require.NoError(t, setGenericTemplate(genericTemplate))
+	}()
+
+	tests := []struct {
+		name      string
+		template  string
+		want      string
+		parseErr  bool
+		renderErr bool
+	}{
+		{
+			name:     "json escaped template",
+			template: `{"summary": {{json (printf "%s: %s" .Experiment .Verdict)}}, "details": {{json .Message}}}`,
+			want:     `{"summary": "pod-delete: Fail \"probe\" breached", "details": "pod-delete experiment has been Failed\nprobe \"http-health\" failed:\t500"}`,
+		},
+		{
+			// the verdict isn't escaped, so the rendered payload isn't a valid json
+			name:      "unescaped template",
+			template:  `{"verdict": "{{.Verdict}}"}`,
+			renderErr: true,
+		},
+		{
+			name:     "invalid template",
+			template: `{"verdict": {{json .Verdict}`,
+			parseErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, setGenericTemplate(genericTemplate))
+			err := setGenericTemplate(tt.template)
+			if tt.parseErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			payload, err := render(genericFormat, verdictNotification)
+			if tt.renderErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(payload))
+		})
+	}
+}
+
+func TestNotifyTemplateOverride(t *testing.T) {
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer server.Close()
+
+	t.Setenv("NOTIFY_WEBHOOK_URLS", "generic="+server.URL)
+	t.Setenv("NOTIFY_EVENTS", "EOT")
+	t.Setenv("NOTIFY_TEMPLATE", `{"text": {{json (printf "%s finished with %s" .Experiment .Verdict)}}, "message": {{json .Message}}, "runID": {{json .RunID}}}`)
+	previous := runNotifier
+	runNotifier = &notifier{}
+	defer func() {
+		runNotifier = previous
+		require.NoError(t, setGenericTemplate(genericTemplate))
+	}()
+
+	Initialise("pod-delete", "nginx-chaos", "litmus", "4f9c1e2a", "abcde")
+	// the filtered events are not sent
+	Send(Notification{Event: StartOfTest})
+	// the terminal events are delivered before the Send returns
+	Send(Notification{Event: EndOfTest, Verdict: verdictNotification.Verdict, Message: verdictNotification.Message})
+
+	select {
+	case body := <-received:
+		payload := map[string]string{}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, map[string]string{
+			"text":    `pod-delete finished with Fail "probe" breached`,
+			"message": verdictNotification.Message,
+			"runID":   "abcde",
+		}, payload)
+	default:
+		t.Fatal("notification is not delivered")
+	}
+	assert.Empty(t, received)
+}
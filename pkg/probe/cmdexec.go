@@ -0,0 +1,194 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/types"
+	litmusexec "github.com/figwood/litmus-go/pkg/utils/exec"
+	"github.com/figwood/litmus-go/pkg/utils/retry"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	apiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// validateCmdProbeExec validate the exec inputs of the cmd probe
+func validateCmdProbeExec(probe v1alpha1.ProbeAttributes, inputs *types.CmdProbeExec) error {
+	if !isInlineProbe(probe.CmdProbeInputs) {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeCmdProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "source and exec can't be used together in the cmd probe"}
+	}
+	switch strings.ToLower(inputs.Policy) {
+	case "", "all", "any", "one":
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeCmdProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("exec policy '%s' not supported in the cmd probe", inputs.Policy)}
+	}
+	return nil
+}
+
+// getCmdProbeExecPods returns the running pods in which the command is executed
+// it returns only one pod, if the policy is one
+func getCmdProbeExecPods(probeName string, inputs *types.CmdProbeExec, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]apiv1.Pod, error) {
+	selectors := getPodSelectors(inputs.Namespace, inputs.LabelSelector, chaosDetails)
+	if len(selectors) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeCmdProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "[Probe]: Any one of labelSelector or chaos targets is required to exec the command"}
+	}
+
+	var pods []apiv1.Pod
+	uids := map[k8stypes.UID]bool{}
+	for _, selector := range selectors {
+		podList, err := clients.KubeClient.CoreV1().Pods(selector.namespace).List(context.Background(), v1.ListOptions{LabelSelector: selector.labelSelector, FieldSelector: selector.fieldSelector})
+		if err != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeCmdProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to list the pods in %s namespace, err: %v", selector.namespace, err)}
+		}
+		for _, pod := range podList.Items {
+			// the terminating pods are skipped, as they are replaced by the new pods
+			if uids[pod.UID] || pod.Status.Phase != apiv1.PodRunning || pod.DeletionTimestamp != nil {
+				continue
+			}
+			uids[pod.UID] = true
+			pods = append(pods, pod)
+		}
+	}
+	if len(pods) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.FailureTypeCmdProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "no running pod found to exec the command"}
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Namespace+"/"+pods[i].Name < pods[j].Namespace+"/"+pods[j].Name
+	})
+	if strings.ToLower(inputs.Policy) == "one" {
+		return pods[:1], nil
+	}
+	return pods, nil
+}
+
+// cmdProbeExecResult contains the outcome of the command in a single pod
+type cmdProbeExecResult struct {
+	pod         string
+	output      string
+	description string
+	err         error
+}
+
+// execCmdProbeInPod runs the command inside the container of the pod and validate the output
+func execCmdProbeInPod(probe v1alpha1.ProbeAttributes, pod apiv1.Pod, container string, clients clients.ClientSets, rc int) cmdProbeExecResult {
+	result := cmdProbeExecResult{pod: pod.Namespace + "/" + pod.Name}
+	if container == "" {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	execCommandDetails := litmusexec.PodDetails{}
+	litmusexec.SetExecCommandAttributes(&execCommandDetails, pod.Name, container, pod.Namespace)
+	output, stdErr, err := litmusexec.Exec(&execCommandDetails, clients, []string{"/bin/sh", "-c", probe.CmdProbeInputs.Command})
+	if err != nil {
+		result.err = cerrors.Error{ErrorCode: cerrors.FailureTypeCmdProbe, Target: fmt.Sprintf("{name: %v, pod: %v}", probe.Name, result.pod), Reason: fmt.Sprintf("unable to exec the command, err: %v", getDescription(err))}
+		return result
+	}
+
+	result.output = strings.TrimSpace(output)
+	if result.description, err = validateResult(probe.CmdProbeInputs.Comparator, probe.Name, probe.RunProperties.Verbosity, result.output, rc); err != nil {
+		if strings.TrimSpace(stdErr) != "" && cerrors.GetErrorType(err) == cerrors.FailureTypeCmdProbe {
+			err = cerrors.Error{ErrorCode: cerrors.FailureTypeCmdProbe, Target: fmt.Sprintf("{name: %v, pod: %v}", probe.Name, result.pod), Reason: strings.TrimSpace(stdErr)}
+		}
+		result.err = err
+	}
+	return result
+}
+
+// aggregateCmdProbeExecResults derive the verdict of the command from the results of all the pods, based on the policy
+// it returns the result of the pod which decides the verdict
+func aggregateCmdProbeExecResults(probe v1alpha1.ProbeAttributes, policy string, results []cmdProbeExecResult) (cmdProbeExecResult, error) {
+	var passed, failed []cmdProbeExecResult
+	for _, result := range results {
+		if result.err != nil {
+			// the errors other than the failures are returned as it is, e.g. unsupported comparator
+			if cerrors.GetErrorType(result.err) != cerrors.FailureTypeCmdProbe {
+				return result, result.err
+			}
+			failed = append(failed, result)
+			continue
+		}
+		passed = append(passed, result)
+	}
+
+	switch strings.ToLower(policy) {
+	case "any":
+		if len(passed) != 0 {
+			return passed[0], nil
+		}
+	default:
+		if len(failed) == 0 {
+			return passed[0], nil
+		}
+	}
+
+	reasons := make([]string, 0, len(failed))
+	for _, result := range failed {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", result.pod, getDescription(result.err)))
+	}
+	return failed[0], cerrors.Error{ErrorCode: cerrors.FailureTypeCmdProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("the command failed in %d/%d pods, %s", len(failed), len(results), strings.Join(reasons, "; "))}
+}
+
+// triggerExecCmdProbe trigger the cmd probe inside the container of the target pods
+func triggerExecCmdProbe(probe v1alpha1.ProbeAttributes, inputs *types.CmdProbeExec, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+	var description string
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+
+	// It parses the templated command and return normal string
+	// if command doesn't have template, it will return the same command
+	command, err := parseCommand(probe.CmdProbeInputs.Command, resultDetails)
+	if err != nil {
+		return err
+	}
+	probe.CmdProbeInputs.Command = command
+
+	// running the cmd probe command inside the pods and matching the output
+	// the pods are derived in each iteration, as the pods may be replaced during chaos
+	if err := retry.Times(uint(getAttempts(probe.RunProperties.Attempt, probe.RunProperties.Retry))).
+		Timeout(probeTimeout.ProbeTimeout).
+		Wait(probeTimeout.Interval).
+		Context(types.AbortContext()).
+		TryWithTimeout(func(attempt uint) error {
+			pods, err := getCmdProbeExecPods(probe.Name, inputs, clients, chaosDetails)
+			if err != nil {
+				return err
+			}
+
+			rc := getAndIncrementRunCount(resultDetails, probe.Name)
+			results := make([]cmdProbeExecResult, 0, len(pods))
+			artifacts := make([]string, 0, len(pods))
+			for _, pod := range pods {
+				result := execCmdProbeInPod(probe, pod, inputs.Container, clients, rc)
+				results = append(results, result)
+				if result.err != nil {
+					artifacts = append(artifacts, fmt.Sprintf("%s: %s", result.pod, getDescription(result.err)))
+				} else {
+					artifacts = append(artifacts, fmt.Sprintf("%s: %s", result.pod, result.description))
+				}
+			}
+			if probeDetails := getProbeByName(probe.Name, resultDetails.ProbeDetails); probeDetails != nil {
+				probeDetails.Artifacts = artifacts
+			}
+
+			result, err := aggregateCmdProbeExecResults(probe, inputs.Policy, results)
+			if err != nil {
+				return err
+			}
+			description = fmt.Sprintf("%s, pod: %s", result.description, result.pod)
+			log.Infof("[Probe]: The %v cmd probe has been executed in %d pods, policy: %v", probe.Name, len(pods), inputs.Policy)
+
+			// register the output along with the named outputs, which can be referenced by the later probes
+			return registerProbeOutput(probe, result.output, []byte(result.output), resultDetails, cerrors.FailureTypeCmdProbe)
+		}); err != nil {
+		return checkProbeTimeoutError(probe.Name, cerrors.FailureTypeCmdProbe, err)
+	}
+
+	setProbeDescription(resultDetails, probe, description)
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
 	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,8 +31,15 @@ import (
 // cmd probe can be used to add the command probes
 // it can be of two types one: which need a source(an external image)
 // another: any inline command which can be run without source image, directly via go-runner image
+// the inline command can also be executed inside the container of the target pods, if exec is provided
 func prepareCmdProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
 
+	if inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).CmdProbeInputs; inputs != nil && inputs.Exec != nil {
+		if err := validateCmdProbeExec(probe, inputs.Exec); err != nil {
+			return err
+		}
+	}
+
 	switch strings.ToLower(phase) {
 	case "prechaos":
 		if err := preChaosCmdProbe(probe, resultDetails, clients, chaosDetails); err != nil {
@@ -52,7 +60,13 @@ func prepareCmdProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 }
 
 // triggerInlineCmdProbe trigger the cmd probe and storing the output into the out buffer
-func triggerInlineCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) error {
+func triggerInlineCmdProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) (err error) {
+	defer observeProbe(probe).end(&err)
+	// the command runs inside the container of the target pods, if exec is provided
+	if inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).CmdProbeInputs; inputs != nil && inputs.Exec != nil {
+		return triggerExecCmdProbe(probe, inputs.Exec, clients, resultDetails, chaosDetails)
+	}
+
 	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
 	var description string
 
@@ -70,6 +84,7 @@ func triggerInlineCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.
 	if err := retry.Times(uint(getAttempts(probe.RunProperties.Attempt, probe.RunProperties.Retry))).
 		Timeout(probeTimeout.ProbeTimeout).
 		Wait(probeTimeout.Interval).
+		Context(types.AbortContext()).
 		TryWithTimeout(func(attempt uint) error {
 			var out, stdErr bytes.Buffer
 			// run the inline command probe
@@ -93,10 +108,9 @@ func triggerInlineCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.
 				return err
 			}
 
-			probes := types.ProbeArtifact{}
-			probes.ProbeArtifacts.Register = strings.TrimSpace(out.String())
-			resultDetails.ProbeArtifacts[probe.Name] = probes
-			return nil
+			// register the output along with the named outputs, which can be referenced by the later probes
+			output := strings.TrimSpace(out.String())
+			return registerProbeOutput(probe, output, []byte(output), resultDetails, cerrors.FailureTypeCmdProbe)
 		}); err != nil {
 		return checkProbeTimeoutError(probe.Name, cerrors.FailureTypeCmdProbe, err)
 	}
@@ -106,7 +120,8 @@ func triggerInlineCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.
 }
 
 // triggerSourceCmdProbe trigger the cmd probe inside the external pod
-func triggerSourceCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+func triggerSourceCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, resultDetails *types.ResultDetails) (err error) {
+	defer observeProbe(probe).end(&err)
 	var description string
 	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
 
@@ -124,6 +139,7 @@ func triggerSourceCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails li
 	if err := retry.Times(uint(getAttempts(probe.RunProperties.Attempt, probe.RunProperties.Retry))).
 		Timeout(probeTimeout.ProbeTimeout).
 		Wait(probeTimeout.Interval).
+		Context(types.AbortContext()).
 		TryWithTimeout(func(attempt uint) error {
 			command := append([]string{"/bin/sh", "-c"}, probe.CmdProbeInputs.Command)
 			// exec inside the external pod to get the o/p of given command
@@ -144,10 +160,9 @@ func triggerSourceCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails li
 				return err
 			}
 
-			probes := types.ProbeArtifact{}
-			probes.ProbeArtifacts.Register = strings.TrimSpace(output)
-			resultDetails.ProbeArtifacts[probe.Name] = probes
-			return nil
+			// register the output along with the named outputs, which can be referenced by the later probes
+			output = strings.TrimSpace(output)
+			return registerProbeOutput(probe, output, []byte(output), resultDetails, cerrors.FailureTypeCmdProbe)
 		}); err != nil {
 		return checkProbeTimeoutError(probe.Name, cerrors.FailureTypeCmdProbe, err)
 	}
@@ -159,7 +174,7 @@ func triggerSourceCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails li
 // createProbePod creates an external pod with source image for the cmd probe
 func createProbePod(clients clients.ClientSets, chaosDetails *types.ChaosDetails, runID string, source *v1alpha1.SourceDetails, probeName string) error {
 	//deriving serviceAccount name for the probe pod
-	svcAccount, err := getServiceAccount(chaosDetails.ChaosNamespace, chaosDetails.ChaosPodName, probeName, clients)
+	expPod, err := getExperimentPod(chaosDetails.ChaosNamespace, chaosDetails.ChaosPodName, probeName, clients)
 	if err != nil {
 		return stacktrace.Propagate(err, "unable to get the serviceAccountName")
 	}
@@ -172,11 +187,19 @@ func createProbePod(clients clients.ClientSets, chaosDetails *types.ChaosDetails
 			Namespace:   chaosDetails.ChaosNamespace,
 			Labels:      getProbeLabels(source.Labels, chaosDetails, runID),
 			Annotations: source.Annotations,
+			// the probe pod is owned by the experiment pod, so that it is garbage collected
+			// even if the experiment pod is terminated before deleting the probe pod
+			OwnerReferences: []v1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Name:       expPod.Name,
+				UID:        expPod.UID,
+			}},
 		},
 		Spec: apiv1.PodSpec{
 			RestartPolicy:      apiv1.RestartPolicyNever,
 			HostNetwork:        source.HostNetwork,
-			ServiceAccountName: svcAccount,
+			ServiceAccountName: expPod.Spec.ServiceAccountName,
 			Volumes:            volume,
 			NodeSelector:       source.NodeSelector,
 			Tolerations:        source.Tolerations,
@@ -301,7 +324,7 @@ func getProbeCmd(sourceCmd []string) []string {
 // deleteProbePod deletes the probe pod and wait until it got terminated
 func deleteProbePod(chaosDetails *types.ChaosDetails, clients clients.ClientSets, runID, probeName string) error {
 
-	if err := clients.KubeClient.CoreV1().Pods(chaosDetails.ChaosNamespace).Delete(context.Background(), chaosDetails.ExperimentName+"-probe-"+runID, v1.DeleteOptions{}); err != nil {
+	if err := clients.KubeClient.CoreV1().Pods(chaosDetails.ChaosNamespace).Delete(context.Background(), chaosDetails.ExperimentName+"-probe-"+runID, v1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeCmdProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: err.Error()}
 	}
 
@@ -310,7 +333,7 @@ func deleteProbePod(chaosDetails *types.ChaosDetails, clients clients.ClientSets
 		Times(uint(chaosDetails.Timeout / chaosDetails.Delay)).
 		Wait(time.Duration(chaosDetails.Delay) * time.Second).
 		Try(func(attempt uint) error {
-			podSpec, err := clients.KubeClient.CoreV1().Pods(chaosDetails.ChaosNamespace).List(context.Background(), v1.ListOptions{LabelSelector: chaosDetails.ExperimentName + "-probe-" + runID})
+			podSpec, err := clients.KubeClient.CoreV1().Pods(chaosDetails.ChaosNamespace).List(context.Background(), v1.ListOptions{LabelSelector: "name=" + chaosDetails.ExperimentName + "-probe-" + runID})
 			if err != nil {
 				return cerrors.Error{ErrorCode: cerrors.ErrorTypeCmdProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("failed to list probe pod: %s", err.Error())}
 			} else if len(podSpec.Items) != 0 {
@@ -345,7 +368,7 @@ loop:
 			}
 			break loop
 		default:
-			err := triggerInlineCmdProbe(probe, chaosresult)
+			err := triggerInlineCmdProbe(probe, clients, chaosresult, chaosDetails)
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 			if err != nil {
 				err = addProbePhase(err, string(chaosDetails.Phase))
@@ -364,6 +387,10 @@ loop:
 			time.Sleep(probeTimeout.ProbePollingInterval)
 		}
 	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
 	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
 	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
 	// and failed the experiment in the end
@@ -406,7 +433,7 @@ loop:
 			break loop
 		default:
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
-			if err = triggerInlineCmdProbe(probe, chaosresult); err != nil {
+			if err = triggerInlineCmdProbe(probe, clients, chaosresult, chaosDetails); err != nil {
 				err = addProbePhase(err, string(chaosDetails.Phase))
 				for index := range chaosresult.ProbeDetails {
 					if chaosresult.ProbeDetails[index].Name == probe.Name {
@@ -434,6 +461,10 @@ loop:
 			}
 		}
 	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
 	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
 	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
 	// and failed the experiment in the end
@@ -454,7 +485,7 @@ func triggerSourceOnChaosCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDet
 	if probeTimeout.InitialDelay != 0 {
 		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
 		time.Sleep(probeTimeout.InitialDelay)
-		duration = math.Maximum(0, duration-int(probeTimeout.InitialDelay))
+		duration = math.Maximum(0, duration-int(probeTimeout.InitialDelay.Seconds()))
 	}
 
 	endTime := time.After(time.Duration(duration) * time.Second)
@@ -474,8 +505,13 @@ loop:
 			}
 			break loop
 		default:
+			iterationStart := time.Now()
+			// verify the readiness of the source pod before each iteration, it is recreated if it is not running anymore
+			if execCommandDetails, err = getSourcePod(probe, chaosresult, clients, chaosDetails); err == nil {
+				err = triggerSourceCmdProbe(probe, execCommandDetails, clients, chaosresult)
+			}
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
-			if err = triggerSourceCmdProbe(probe, execCommandDetails, clients, chaosresult); err != nil {
+			if err != nil {
 				err = addProbePhase(err, string(chaosDetails.Phase))
 				for index := range chaosresult.ProbeDetails {
 					if chaosresult.ProbeDetails[index].Name == probe.Name {
@@ -499,11 +535,15 @@ loop:
 				}
 				break loop
 			default:
-				// waiting for the probe polling interval
-				time.Sleep(probeTimeout.ProbePollingInterval)
+				// waiting for the remaining probe polling interval
+				waitForNextIteration(iterationStart, probeTimeout.ProbePollingInterval)
 			}
 		}
 	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
 	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
 	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
 	// and failed the experiment in the end
@@ -542,7 +582,11 @@ loop:
 			break loop
 
 		default:
-			err = triggerSourceCmdProbe(probe, execCommandDetails, clients, chaosresult)
+			iterationStart := time.Now()
+			// verify the readiness of the source pod before each iteration, it is recreated if it is not running anymore
+			if execCommandDetails, err = getSourcePod(probe, chaosresult, clients, chaosDetails); err == nil {
+				err = triggerSourceCmdProbe(probe, execCommandDetails, clients, chaosresult)
+			}
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 			if err != nil {
 				err = addProbePhase(err, string(chaosDetails.Phase))
@@ -557,10 +601,14 @@ loop:
 					}
 				}
 			}
-			// waiting for the probe polling interval
-			time.Sleep(probeTimeout.ProbePollingInterval)
+			// waiting for the remaining probe polling interval
+			waitForNextIteration(iterationStart, probeTimeout.ProbePollingInterval)
 		}
 	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
 	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
 	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
 	// and failed the experiment in the end
@@ -571,8 +619,14 @@ loop:
 	}
 }
 
+// waitForNextIteration waits for the remaining polling interval since the start of the iteration
+// so that the iterations are triggered at the configured interval, irrespective of the execution time of the command
+func waitForNextIteration(iterationStart time.Time, interval time.Duration) {
+	time.Sleep(interval - time.Since(iterationStart))
+}
+
 // validateResult validate the probe result to specified comparison operation
-// it supports int, float, string operands
+// it supports int, float, string, semver, duration and timestamp operands
 func validateResult(comparator v1alpha1.ComparatorInfo, probeName, probeVerbosity string, cmdOutput string, rc int) (string, error) {
 
 	compare := cmp.RunCount(rc).
@@ -582,22 +636,12 @@ func validateResult(comparator v1alpha1.ComparatorInfo, probeName, probeVerbosit
 		ProbeName(probeName).
 		ProbeVerbosity(probeVerbosity)
 
-	switch strings.ToLower(comparator.Type) {
-	case "int":
-		if err = compare.CompareInt(cerrors.FailureTypeCmdProbe); err != nil {
-			return "", err
-		}
-	case "float":
-		if err = compare.CompareFloat(cerrors.FailureTypeCmdProbe); err != nil {
-			return "", err
-		}
-	case "string":
-		if err = compare.CompareString(cerrors.FailureTypeCmdProbe); err != nil {
-			return "", err
-		}
-	default:
+	if !cmp.IsSupportedType(comparator.Type) {
 		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeCmdProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("comparator type '%s' not supported in the cmd probe", comparator.Type)}
 	}
+	if err = compare.Compare(comparator.Type, cerrors.FailureTypeCmdProbe); err != nil {
+		return "", err
+	}
 	description := fmt.Sprintf("Actual value: '%s'. Expected value: '%s'", cmdOutput, comparator.Value)
 	return description, nil
 }
@@ -628,7 +672,7 @@ func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 
 		// triggering the cmd probe for the inline mode
 		if isInlineProbe(probe.CmdProbeInputs) {
-			if err = triggerInlineCmdProbe(probe, resultDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeCmdProbe {
+			if err = triggerInlineCmdProbe(probe, clients, resultDetails, chaosDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeCmdProbe {
 				return err
 			}
 
@@ -639,7 +683,7 @@ func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			}
 		} else {
 
-			execCommandDetails, err := createHelperPod(probe, resultDetails, clients, chaosDetails)
+			execCommandDetails, err := getSourcePod(probe, resultDetails, clients, chaosDetails)
 			if err != nil {
 				return err
 			}
@@ -654,14 +698,6 @@ func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			if err = markedVerdictInEnd(err, resultDetails, probe, "PreChaos"); err != nil {
 				return err
 			}
-
-			// get runId
-			runID := getRunIDFromProbe(resultDetails, probe.Name, probe.Type)
-
-			// deleting the external pod which was created for cmd probe
-			if err = deleteProbePod(chaosDetails, clients, runID, probe.Name); err != nil {
-				return err
-			}
 		}
 
 	case "Continuous":
@@ -680,7 +716,7 @@ func preChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			go triggerInlineContinuousCmdProbe(probe, clients, resultDetails, chaosDetails)
 		} else {
 
-			execCommandDetails, err := createHelperPod(probe, resultDetails, clients, chaosDetails)
+			execCommandDetails, err := getSourcePod(probe, resultDetails, clients, chaosDetails)
 			if err != nil {
 				return err
 			}
@@ -719,7 +755,7 @@ func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 
 		// triggering the cmd probe for the inline mode
 		if isInlineProbe(probe.CmdProbeInputs) {
-			if err = triggerInlineCmdProbe(probe, resultDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeCmdProbe {
+			if err = triggerInlineCmdProbe(probe, clients, resultDetails, chaosDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeCmdProbe {
 				return err
 			}
 
@@ -730,7 +766,7 @@ func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 			}
 		} else {
 
-			execCommandDetails, err := createHelperPod(probe, resultDetails, clients, chaosDetails)
+			execCommandDetails, err := getSourcePod(probe, resultDetails, clients, chaosDetails)
 			if err != nil {
 				return err
 			}
@@ -745,42 +781,16 @@ func postChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 			if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
 				return err
 			}
-
-			// get runId
-			runID := getRunIDFromProbe(resultDetails, probe.Name, probe.Type)
-
-			// deleting the external pod which was created for cmd probe
-			if err = deleteProbePod(chaosDetails, clients, runID, probe.Name); err != nil {
-				return err
-			}
 		}
 	case "Continuous", "OnChaos":
-		if isInlineProbe(probe.CmdProbeInputs) {
-			// it will check for the error, It will detect the error if any error encountered in probe during chaos
-			if err = checkForErrorInContinuousProbe(resultDetails, probe.Name, chaosDetails.Delay, chaosDetails.Timeout); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeCmdProbe && cerrors.GetErrorType(err) != cerrors.FailureTypeProbeTimeout {
-				return err
-			}
-			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
-			if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
-				return err
-			}
-		} else {
-			// it will check for the error, It will detect the error if any error encountered in probe during chaos
-			if err = checkForErrorInContinuousProbe(resultDetails, probe.Name, chaosDetails.Delay, chaosDetails.Timeout); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeCmdProbe && cerrors.GetErrorType(err) != cerrors.FailureTypeProbeTimeout {
-				return err
-			}
-
-			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
-			if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
-				return err
-			}
-			// get runId
-			runID := getRunIDFromProbe(resultDetails, probe.Name, probe.Type)
-			// deleting the external pod, which was created for cmd probe
-			if err = deleteProbePod(chaosDetails, clients, runID, probe.Name); err != nil {
-				return err
-			}
-
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		// the source pod is deleted at the end of the experiment, via CleanupProbes
+		if err = checkForErrorInContinuousProbe(resultDetails, probe.Name, chaosDetails.Delay, chaosDetails.Timeout); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeCmdProbe && cerrors.GetErrorType(err) != cerrors.FailureTypeProbeTimeout {
+			return err
+		}
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -806,7 +816,7 @@ func onChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 			go triggerInlineOnChaosCmdProbe(probe, clients, resultDetails, chaosDetails)
 		} else {
 
-			execCommandDetails, err := createHelperPod(probe, resultDetails, clients, chaosDetails)
+			execCommandDetails, err := getSourcePod(probe, resultDetails, clients, chaosDetails)
 			if err != nil {
 				return err
 			}
@@ -818,9 +828,22 @@ func onChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 	return nil
 }
 
-// createHelperPod create the helper pod with the source image
-// it will be created if the mode is not inline
-func createHelperPod(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (litmusexec.PodDetails, error) {
+// getSourcePod returns the source pod of the cmd probe
+// it will be created if the mode is not inline. the source pod is created once per probe and reused in all the phases
+// and iterations, it is recreated only if the existing pod is not running anymore
+func getSourcePod(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (litmusexec.PodDetails, error) {
+	if runID := getRunIDFromProbe(resultDetails, probe.Name, probe.Type); runID != "" {
+		if isSourcePodRunning(chaosDetails, clients, runID) {
+			execCommandDetails := litmusexec.PodDetails{}
+			litmusexec.SetExecCommandAttributes(&execCommandDetails, chaosDetails.ExperimentName+"-probe-"+runID, chaosDetails.ExperimentName+"-probe", chaosDetails.ChaosNamespace)
+			return execCommandDetails, nil
+		}
+		log.Warnf("[Probe]: The source pod of %v probe is not running, recreating the source pod", probe.Name)
+		if err := deleteProbePod(chaosDetails, clients, runID, probe.Name); err != nil {
+			return litmusexec.PodDetails{}, err
+		}
+	}
+
 	// Generate the run_id
 	runID := stringutils.GetRunID()
 	setRunIDForProbe(resultDetails, probe.Name, probe.Type, runID)
@@ -843,13 +866,38 @@ func createHelperPod(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 	return execCommandDetails, nil
 }
 
-// getServiceAccount derive the serviceAccountName for the probe pod
-func getServiceAccount(chaosNamespace, chaosPodName, probeName string, clients clients.ClientSets) (string, error) {
+// getExperimentPod returns the experiment pod, which is used to derive the serviceAccountName and owner of the probe pod
+func getExperimentPod(chaosNamespace, chaosPodName, probeName string, clients clients.ClientSets) (*apiv1.Pod, error) {
 	pod, err := clients.KubeClient.CoreV1().Pods(chaosNamespace).Get(context.Background(), chaosPodName, v1.GetOptions{})
 	if err != nil {
-		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeCmdProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: err.Error()}
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeCmdProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: err.Error()}
+	}
+	return pod, nil
+}
+
+// isSourcePodRunning checks whether the source pod is running and not terminating
+func isSourcePodRunning(chaosDetails *types.ChaosDetails, clients clients.ClientSets, runID string) bool {
+	pod, err := clients.KubeClient.CoreV1().Pods(chaosDetails.ChaosNamespace).Get(context.Background(), chaosDetails.ExperimentName+"-probe-"+runID, v1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	return pod.Status.Phase == apiv1.PodRunning && pod.DeletionTimestamp == nil
+}
+
+// deleteSourcePods deletes the source pods of all the cmd probes
+// the source pods are kept for the entire experiment, so these are deleted at the end, failure or abort of the experiment, via CleanupProbes
+func deleteSourcePods(resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, clients clients.ClientSets) {
+	for _, probe := range resultDetails.ProbeDetails {
+		if probe.RunID == "" {
+			continue
+		}
+		log.Infof("[Cleanup]: Deleting the source pod of %v probe", probe.Name)
+		if err := deleteProbePod(chaosDetails, clients, probe.RunID, probe.Name); err != nil {
+			log.Errorf("unable to delete the source pod of %v probe, err: %v", probe.Name, err)
+			continue
+		}
+		probe.RunID = ""
 	}
-	return pod.Spec.ServiceAccountName, nil
 }
 
 func isInlineProbe(inputs *v1alpha1.CmdProbeInputs) bool {
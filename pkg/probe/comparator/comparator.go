@@ -1,5 +1,14 @@
 package comparator
 
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/log"
+)
+
 // Model contains operands and operator for the comparison operations
 // a and b attribute belongs to operands and operator attribute belongs to operator
 type Model struct {
@@ -58,3 +67,110 @@ func (model *Model) ProbeVerbosity(verbosity string) *Model {
 	model.probeVerbosity = verbosity
 	return model
 }
+
+// Compare compares the operands based on the type of the comparator
+// it supports int, float, string, semver, duration and timestamp types
+func (model Model) Compare(comparatorType string, errorCode cerrors.ErrorType) error {
+	switch strings.ToLower(comparatorType) {
+	case "int":
+		return model.CompareInt(errorCode)
+	case "float":
+		return model.CompareFloat(errorCode)
+	case "string":
+		return model.CompareString(errorCode)
+	case "semver":
+		return model.CompareSemver(errorCode)
+	case "duration":
+		return model.CompareDuration(errorCode)
+	case "timestamp":
+		return model.CompareTimestamp(errorCode)
+	default:
+		return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("comparator type '%s' not supported in the probe", comparatorType)}
+	}
+}
+
+// IsSupportedType checks whether the comparator type is supported by the Compare
+func IsSupportedType(comparatorType string) bool {
+	switch strings.ToLower(comparatorType) {
+	case "int", "float", "string", "semver", "duration", "timestamp":
+		return true
+	}
+	return false
+}
+
+// splitValues splits the comma separated list of expected values
+// the list can be enclosed in square brackets, e.g. [1,5]
+func splitValues(b string) []string {
+	b = strings.TrimSpace(b)
+	if strings.HasPrefix(b, "[") && strings.HasSuffix(b, "]") {
+		b = b[1 : len(b)-1]
+	}
+	values := strings.Split(b, ",")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
+
+// expectedValues returns the expected values of the ordered types
+// only the oneOf and between operators accept the list, so that the single value can contain the comma, e.g. RFC1123 timestamp
+func (model Model) expectedValues() []string {
+	b := reflect.ValueOf(model.b).String()
+	switch model.operator {
+	case "OneOf", "oneOf", "between", "Between":
+		return splitValues(b)
+	}
+	return []string{strings.TrimSpace(b)}
+}
+
+// compareOrder checks the operator for the types whose values can be ordered, e.g. semver, duration and timestamp
+// order returns -1, 0 or +1 if the actual value is lesser than, equal to or greater than the ith expected value
+func (model Model) compareOrder(errorCode cerrors.ErrorType, a string, c []string, order func(i int) int) error {
+	if model.probeVerbosity != "info" || (model.probeVerbosity == "info" && model.rc == 1) {
+		log.Infof("[Probe]: {Actual value: %v}, {Expected value: %v}, {Operator: %v}", a, strings.Join(c, ","), model.operator)
+	}
+
+	switch model.operator {
+	case ">=":
+		if order(0) < 0 {
+			return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Actual value: %v. Expected value: greater than or equal to %v", a, c[0])}
+		}
+	case "<=":
+		if order(0) > 0 {
+			return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Actual value: %v. Expected value: lesser than or equal to %v", a, c[0])}
+		}
+	case ">":
+		if order(0) <= 0 {
+			return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Actual value: %v. Expected value: greater than %v", a, c[0])}
+		}
+	case "<":
+		if order(0) >= 0 {
+			return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Actual value: %v. Expected value: lesser than %v", a, c[0])}
+		}
+	case "==":
+		if order(0) != 0 {
+			return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Actual value: %v. Expected value: equal to %v", a, c[0])}
+		}
+	case "!=":
+		if order(0) == 0 {
+			return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Actual value: %v. Expectation: should not match the provided value: %v", a, c[0])}
+		}
+	case "OneOf", "oneOf":
+		for i := range c {
+			if order(i) == 0 {
+				return nil
+			}
+		}
+		return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Actual value: %v doesn't matched any of the Expected values: [%v]", a, strings.Join(c, ","))}
+	case "between", "Between":
+		if len(c) < 2 {
+			return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("The expected value %v should specify both lower and upper limits", c)}
+		}
+		if order(0) < 0 || order(1) > 0 {
+			return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Actual value: %v doesn't lie in between the Expected range: [%v]", a, strings.Join(c, ","))}
+		}
+	default:
+		return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("criteria '%s' not supported in the probe", model.operator)}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package comparator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+)
+
+// CompareDuration compares durations for specific operation
+// it check for the >=, >, <=, <, ==, !=, oneOf and between operators
+func (model Model) CompareDuration(errorCode cerrors.ErrorType) error {
+
+	a := strings.TrimSpace(reflect.ValueOf(model.a).String())
+	c := model.expectedValues()
+
+	actual, err := parseDuration(a)
+	if err != nil {
+		return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Actual value: %v is not a valid duration, err: %v", a, err)}
+	}
+	expected := make([]time.Duration, len(c))
+	for i := range c {
+		if expected[i], err = parseDuration(c[i]); err != nil {
+			return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Expected value: %v is not a valid duration, err: %v", c[i], err)}
+		}
+	}
+
+	return model.compareOrder(errorCode, a, c, func(i int) int {
+		return compareDuration(actual, expected[i])
+	})
+}
+
+// parseDuration parses the duration, e.g. 1h30m, 30d or 90
+// it supports the days(d) unit in addition to the go durations, the plain numbers are considered as seconds
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.ParseFloat(strings.TrimSuffix(value, "d"), 64); err == nil {
+			return time.Duration(days * float64(24*time.Hour)), nil
+		}
+	}
+	return time.ParseDuration(value)
+}
+
+// compareDuration returns -1, 0 or +1 if the first duration is lesser than, equal to or greater than the second duration
+func compareDuration(a, b time.Duration) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
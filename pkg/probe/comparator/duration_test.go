@@ -0,0 +1,86 @@
+package comparator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		actual   string
+		criteria string
+		expected string
+		wantErr  bool
+	}{
+		{name: ">= greater", actual: "2m", criteria: ">=", expected: "90s"},
+		{name: ">= equal", actual: "1m30s", criteria: ">=", expected: "90"},
+		{name: ">= lesser", actual: "1m", criteria: ">=", expected: "90s", wantErr: true},
+		{name: "<= lesser", actual: "500ms", criteria: "<=", expected: "1s"},
+		{name: "<= equal", actual: "24h", criteria: "<=", expected: "1d"},
+		{name: "<= greater", actual: "2s", criteria: "<=", expected: "1s", wantErr: true},
+		{name: "> greater", actual: "31d", criteria: ">", expected: "30d"},
+		{name: "> equal", actual: "1h", criteria: ">", expected: "60m", wantErr: true},
+		{name: "< lesser", actual: "0.5", criteria: "<", expected: "1s"},
+		{name: "< equal", actual: "1s", criteria: "<", expected: "1000ms", wantErr: true},
+		{name: "== equal", actual: "1.5d", criteria: "==", expected: "36h"},
+		{name: "== not equal", actual: "1h", criteria: "==", expected: "2h", wantErr: true},
+		{name: "!= not equal", actual: "1h", criteria: "!=", expected: "2h"},
+		{name: "!= equal", actual: "3600", criteria: "!=", expected: "1h", wantErr: true},
+		{name: "oneOf matched", actual: "5m", criteria: "oneOf", expected: "[1m, 300s]"},
+		{name: "OneOf not matched", actual: "5m", criteria: "OneOf", expected: "1m,2m", wantErr: true},
+		{name: "between inside", actual: "250ms", criteria: "between", expected: "100ms,500ms"},
+		{name: "between upper limit", actual: "500ms", criteria: "Between", expected: "100ms,500ms"},
+		{name: "between outside", actual: "50ms", criteria: "between", expected: "100ms,500ms", wantErr: true},
+		{name: "between single limit", actual: "250ms", criteria: "between", expected: "100ms", wantErr: true},
+		{name: "unsupported criteria", actual: "1s", criteria: "equal", expected: "1s", wantErr: true},
+		{name: "negative duration", actual: "-1s", criteria: "<", expected: "0"},
+		{name: "invalid actual", actual: "soon", criteria: "<", expected: "1s", wantErr: true},
+		{name: "invalid expected", actual: "1s", criteria: "<", expected: "1 minute", wantErr: true},
+		{name: "invalid days", actual: "1s", criteria: "<", expected: "xd", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FirstValue(tt.actual).SecondValue(tt.expected).Criteria(tt.criteria).ProbeName("http-probe").Compare("duration", cerrors.FailureTypeHttpProbe)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, cerrors.FailureTypeHttpProbe, cerrors.GetErrorType(err))
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "90", want: 90 * time.Second},
+		{value: "0.25", want: 250 * time.Millisecond},
+		{value: "1h30m", want: 90 * time.Minute},
+		{value: "30d", want: 30 * 24 * time.Hour},
+		{value: "1.5d", want: 36 * time.Hour},
+		{value: " 10s ", want: 10 * time.Second},
+		{value: "", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "10 days", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseDuration(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
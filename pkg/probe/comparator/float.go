@@ -73,8 +73,8 @@ type Float struct {
 // SetValues set the values inside Float struct
 func (f *Float) setValues(a, b string) {
 
-	f.a, _ = strconv.ParseFloat(a, 64)
-	c := strings.Split(strings.TrimSpace(b), ",")
+	f.a, _ = strconv.ParseFloat(strings.TrimSpace(a), 64)
+	c := splitValues(b)
 	if len(c) > 1 {
 		list := []float64{}
 		for j := range c {
@@ -84,7 +84,7 @@ func (f *Float) setValues(a, b string) {
 		f.c = list
 		f.b = float64(0)
 	} else {
-		f.b, _ = strconv.ParseFloat(b, 64)
+		f.b, _ = strconv.ParseFloat(strings.TrimSpace(b), 64)
 	}
 }
 
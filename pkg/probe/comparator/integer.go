@@ -73,8 +73,8 @@ type Integer struct {
 // SetValues sets the value inside Integer struct
 func (i *Integer) setValues(a, b string) {
 
-	i.a, _ = strconv.Atoi(a)
-	c := strings.Split(strings.TrimSpace(b), ",")
+	i.a, _ = strconv.Atoi(strings.TrimSpace(a))
+	c := splitValues(b)
 	if len(c) > 1 {
 		list := []int{}
 		for j := range c {
@@ -84,7 +84,7 @@ func (i *Integer) setValues(a, b string) {
 		i.c = list
 		i.b = 0
 	} else {
-		i.b, _ = strconv.Atoi(b)
+		i.b, _ = strconv.Atoi(strings.TrimSpace(b))
 	}
 }
 
@@ -0,0 +1,130 @@
+package comparator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+)
+
+// CompareSemver compares semantic versions for specific operation
+// it check for the >=, >, <=, <, ==, !=, oneOf and between operators
+func (model Model) CompareSemver(errorCode cerrors.ErrorType) error {
+
+	a := strings.TrimSpace(reflect.ValueOf(model.a).String())
+	c := model.expectedValues()
+
+	actual, err := parseSemver(a)
+	if err != nil {
+		return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Actual value: %v is not a valid semantic version, err: %v", a, err)}
+	}
+	expected := make([]Semver, len(c))
+	for i := range c {
+		if expected[i], err = parseSemver(c[i]); err != nil {
+			return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Expected value: %v is not a valid semantic version, err: %v", c[i], err)}
+		}
+	}
+
+	return model.compareOrder(errorCode, a, c, func(i int) int {
+		return actual.compare(expected[i])
+	})
+}
+
+// Semver contains the parsed semantic version
+type Semver struct {
+	major      uint64
+	minor      uint64
+	patch      uint64
+	preRelease []string
+}
+
+// parseSemver parses the semantic version, e.g. v1.21.2 or 1.2.3-rc.1+build.5
+// the build metadata is ignored, the missing minor and patch versions are considered as zero
+func parseSemver(version string) (Semver, error) {
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.Index(v, "+"); i >= 0 {
+		v = v[:i]
+	}
+
+	var semver Semver
+	if i := strings.Index(v, "-"); i >= 0 {
+		semver.preRelease = strings.Split(v[i+1:], ".")
+		v = v[:i]
+		for _, identifier := range semver.preRelease {
+			if identifier == "" {
+				return semver, fmt.Errorf("pre-release identifiers should not be empty")
+			}
+		}
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return semver, fmt.Errorf("version should have at most three numeric identifiers")
+	}
+	numbers := []*uint64{&semver.major, &semver.minor, &semver.patch}
+	for i := range parts {
+		number, err := strconv.ParseUint(parts[i], 10, 64)
+		if err != nil {
+			return semver, fmt.Errorf("'%s' is not a numeric identifier", parts[i])
+		}
+		*numbers[i] = number
+	}
+	return semver, nil
+}
+
+// compare returns the precedence of the version relative to the other version
+func (s Semver) compare(other Semver) int {
+	if c := compareUint(s.major, other.major); c != 0 {
+		return c
+	}
+	if c := compareUint(s.minor, other.minor); c != 0 {
+		return c
+	}
+	if c := compareUint(s.patch, other.patch); c != 0 {
+		return c
+	}
+
+	// a pre-release version has lower precedence than the normal version
+	switch {
+	case len(s.preRelease) == 0 && len(other.preRelease) == 0:
+		return 0
+	case len(s.preRelease) == 0:
+		return 1
+	case len(other.preRelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(s.preRelease) && i < len(other.preRelease); i++ {
+		x, errX := strconv.ParseUint(s.preRelease[i], 10, 64)
+		y, errY := strconv.ParseUint(other.preRelease[i], 10, 64)
+		switch {
+		case errX == nil && errY == nil:
+			if c := compareUint(x, y); c != 0 {
+				return c
+			}
+		// numeric identifiers have lower precedence than the alphanumeric identifiers
+		case errX == nil:
+			return -1
+		case errY == nil:
+			return 1
+		default:
+			if c := strings.Compare(s.preRelease[i], other.preRelease[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareUint(uint64(len(s.preRelease)), uint64(len(other.preRelease)))
+}
+
+// compareUint returns -1, 0 or +1 if the first number is lesser than, equal to or greater than the second number
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
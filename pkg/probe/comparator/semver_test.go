@@ -0,0 +1,125 @@
+package comparator
+
+import (
+	"testing"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareSemver(t *testing.T) {
+	tests := []struct {
+		name     string
+		actual   string
+		criteria string
+		expected string
+		wantErr  bool
+	}{
+		{name: ">= greater", actual: "1.21.3", criteria: ">=", expected: "1.21.0"},
+		{name: ">= equal", actual: "1.21.0", criteria: ">=", expected: "1.21.0"},
+		{name: ">= lesser", actual: "1.20.9", criteria: ">=", expected: "1.21.0", wantErr: true},
+		{name: "<= lesser", actual: "1.2.3", criteria: "<=", expected: "1.10.0"},
+		{name: "<= equal", actual: "1.10.0", criteria: "<=", expected: "1.10.0"},
+		{name: "<= greater", actual: "2.0.0", criteria: "<=", expected: "1.10.0", wantErr: true},
+		{name: "> greater", actual: "1.0.1", criteria: ">", expected: "1.0.0"},
+		{name: "> equal", actual: "1.0.0", criteria: ">", expected: "1.0.0", wantErr: true},
+		{name: "< lesser", actual: "0.9.9", criteria: "<", expected: "1.0.0"},
+		{name: "< equal", actual: "1.0.0", criteria: "<", expected: "1.0.0", wantErr: true},
+		{name: "== equal", actual: "v1.2.3", criteria: "==", expected: "1.2.3"},
+		{name: "== not equal", actual: "1.2.3", criteria: "==", expected: "1.2.4", wantErr: true},
+		{name: "!= not equal", actual: "1.2.3", criteria: "!=", expected: "1.2.4"},
+		{name: "!= equal", actual: "1.2.3", criteria: "!=", expected: "1.2.3", wantErr: true},
+		{name: "oneOf matched", actual: "1.2.3", criteria: "oneOf", expected: "[1.2.2, 1.2.3]"},
+		{name: "OneOf not matched", actual: "1.2.4", criteria: "OneOf", expected: "1.2.2,1.2.3", wantErr: true},
+		{name: "between inside", actual: "1.5.0", criteria: "between", expected: "1.0.0,2.0.0"},
+		{name: "between lower limit", actual: "1.0.0", criteria: "Between", expected: "1.0.0,2.0.0"},
+		{name: "between outside", actual: "2.0.1", criteria: "between", expected: "1.0.0,2.0.0", wantErr: true},
+		{name: "between single limit", actual: "1.5.0", criteria: "between", expected: "1.0.0", wantErr: true},
+		{name: "unsupported criteria", actual: "1.0.0", criteria: "equal", expected: "1.0.0", wantErr: true},
+		{name: "missing minor and patch", actual: "2", criteria: "==", expected: "2.0.0"},
+		{name: "build metadata ignored", actual: "1.0.0+build.1", criteria: "==", expected: "1.0.0+build.2"},
+		{name: "pre-release lesser than release", actual: "1.0.0-rc.1", criteria: "<", expected: "1.0.0"},
+		{name: "pre-release greater than previous release", actual: "1.0.0-alpha", criteria: ">", expected: "0.9.9"},
+		{name: "invalid actual", actual: "one.two", criteria: ">=", expected: "1.0.0", wantErr: true},
+		{name: "invalid expected", actual: "1.0.0", criteria: ">=", expected: "1.0.0.0", wantErr: true},
+		{name: "empty actual", actual: "", criteria: "==", expected: "1.0.0", wantErr: true},
+		{name: "empty pre-release", actual: "1.0.0-", criteria: "<", expected: "1.0.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FirstValue(tt.actual).SecondValue(tt.expected).Criteria(tt.criteria).ProbeName("cmd-probe").Compare("semver", cerrors.FailureTypeCmdProbe)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, cerrors.FailureTypeCmdProbe, cerrors.GetErrorType(err))
+		})
+	}
+}
+
+func TestSemverPreReleaseOrder(t *testing.T) {
+	// precedence as per the semver spec, each version is lesser than the next one
+	versions := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+	for i := range versions {
+		for j := range versions {
+			a, err := parseSemver(versions[i])
+			require.NoError(t, err)
+			b, err := parseSemver(versions[j])
+			require.NoError(t, err)
+
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			assert.Equal(t, want, a.compare(b), "%s compared to %s", versions[i], versions[j])
+		}
+	}
+}
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		version string
+		want    Semver
+		wantErr bool
+	}{
+		{version: "1.2.3", want: Semver{major: 1, minor: 2, patch: 3}},
+		{version: "v1.2.3", want: Semver{major: 1, minor: 2, patch: 3}},
+		{version: "1.2", want: Semver{major: 1, minor: 2}},
+		{version: "1.2.3-rc.1+build.5", want: Semver{major: 1, minor: 2, patch: 3, preRelease: []string{"rc", "1"}}},
+		{version: "1.2.3+build.5", want: Semver{major: 1, minor: 2, patch: 3}},
+		{version: "", wantErr: true},
+		{version: "1.2.3.4", wantErr: true},
+		{version: "1.-2.3", wantErr: true},
+		{version: "1.x.3", wantErr: true},
+		{version: "1.2.3-", wantErr: true},
+		{version: "1.2.3-alpha..1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := parseSemver(tt.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
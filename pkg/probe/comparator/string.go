@@ -11,7 +11,7 @@ import (
 )
 
 // CompareString compares strings for specific operation
-// it check for the equal, not equal and contains(sub-string) operations
+// it check for the equal, not equal, contains(sub-string), matches(regex) and oneOf operations
 func (model Model) CompareString(errorCode cerrors.ErrorType) error {
 
 	obj := String{}
@@ -68,16 +68,11 @@ type String struct {
 }
 
 // SetValues sets the values inside String struct
+// the expected value is kept as it is, so that it can contain the commas, e.g. regex
 func (s *String) setValues(a, b string) {
-
 	s.a = a
-	c := strings.Split(strings.TrimSpace(b), ",")
-	if len(c) > 1 {
-		s.c = c
-		s.b = ""
-	} else {
-		s.b = b
-	}
+	s.b = b
+	s.c = splitValues(b)
 }
 
 // isEqual check for the first string should be equals to second string
@@ -0,0 +1,80 @@
+package comparator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+)
+
+// timestampLayouts contains the supported layouts of the timestamp
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC1123,
+	time.RFC1123Z,
+	"2006-01-02 15:04:05",
+	// layout used by openssl, e.g. openssl x509 -enddate
+	"Jan _2 15:04:05 2006 MST",
+}
+
+// CompareTimestamp compares timestamps for specific operation
+// it check for the >=, >, <=, <, ==, !=, oneOf and between operators
+// the expected value can be relative to the current time, e.g. now-5m for the age and now+30d for the expiry
+func (model Model) CompareTimestamp(errorCode cerrors.ErrorType) error {
+
+	a := strings.TrimSpace(reflect.ValueOf(model.a).String())
+	c := model.expectedValues()
+
+	now := time.Now()
+	actual, err := parseTimestamp(a, now)
+	if err != nil {
+		return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Actual value: %v is not a valid timestamp, err: %v", a, err)}
+	}
+	expected := make([]time.Time, len(c))
+	for i := range c {
+		if expected[i], err = parseTimestamp(c[i], now); err != nil {
+			return cerrors.Error{ErrorCode: errorCode, Target: model.probeName, Reason: fmt.Sprintf("Expected value: %v is not a valid timestamp, err: %v", c[i], err)}
+		}
+	}
+
+	return model.compareOrder(errorCode, a, c, func(i int) int {
+		switch {
+		case actual.Before(expected[i]):
+			return -1
+		case actual.After(expected[i]):
+			return 1
+		}
+		return 0
+	})
+}
+
+// parseTimestamp parses the timestamp in the supported layouts or unix seconds
+// it also parses the timestamp relative to the given time, e.g. now, now-5m or now+30d
+func parseTimestamp(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, "now") {
+		offset := strings.TrimPrefix(value, "now")
+		if offset == "" {
+			return now, nil
+		}
+		duration, err := parseDuration(strings.TrimPrefix(offset, "+"))
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(duration), nil
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	for _, layout := range timestampLayouts {
+		if timestamp, err := time.Parse(layout, value); err == nil {
+			return timestamp, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("timestamp should be in RFC3339, RFC1123 or unix seconds format")
+}
@@ -0,0 +1,94 @@
+package comparator
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareTimestamp(t *testing.T) {
+	// the actual values are relative to the current time, with the margin wide enough for the test run
+	hourAgo := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	inTenDays := time.Now().Add(10 * 24 * time.Hour).Format(time.RFC1123)
+
+	tests := []struct {
+		name     string
+		actual   string
+		criteria string
+		expected string
+		wantErr  bool
+	}{
+		{name: ">= greater", actual: hourAgo, criteria: ">=", expected: "now-2h"},
+		{name: ">= lesser", actual: hourAgo, criteria: ">=", expected: "now-5m", wantErr: true},
+		{name: "<= lesser", actual: hourAgo, criteria: "<=", expected: "now"},
+		{name: "<= greater", actual: inTenDays, criteria: "<=", expected: "now+1d", wantErr: true},
+		{name: "> greater", actual: inTenDays, criteria: ">", expected: "now+7d"},
+		{name: "> lesser", actual: inTenDays, criteria: ">", expected: "now+30d", wantErr: true},
+		{name: "< lesser", actual: hourAgo, criteria: "<", expected: "now-30m"},
+		{name: "< greater", actual: hourAgo, criteria: "<", expected: "now-90m", wantErr: true},
+		{name: "== equal", actual: "2024-03-01T10:00:00Z", criteria: "==", expected: "1709287200"},
+		{name: "== other zone", actual: "2024-03-01T12:00:00+02:00", criteria: "==", expected: "2024-03-01T10:00:00Z"},
+		{name: "== not equal", actual: "2024-03-01T10:00:00Z", criteria: "==", expected: "2024-03-01T10:00:01Z", wantErr: true},
+		{name: "!= not equal", actual: "2024-03-01T10:00:00Z", criteria: "!=", expected: "2024-03-02T10:00:00Z"},
+		{name: "!= equal", actual: "1709287200", criteria: "!=", expected: "2024-03-01T10:00:00Z", wantErr: true},
+		{name: "oneOf matched", actual: "2024-03-01 10:00:00", criteria: "oneOf", expected: "[2024-02-01T10:00:00Z, 2024-03-01T10:00:00Z]"},
+		{name: "OneOf not matched", actual: "2024-03-01T10:00:00Z", criteria: "OneOf", expected: "1709287201,1709287202", wantErr: true},
+		{name: "between inside", actual: hourAgo, criteria: "between", expected: "now-2h,now"},
+		{name: "between outside", actual: inTenDays, criteria: "Between", expected: "now-2h,now", wantErr: true},
+		{name: "between single limit", actual: hourAgo, criteria: "between", expected: "now-2h", wantErr: true},
+		{name: "unsupported criteria", actual: hourAgo, criteria: "before", expected: "now", wantErr: true},
+		{name: "RFC1123 expected value", actual: "2024-03-01T10:00:00Z", criteria: "==", expected: "Fri, 01 Mar 2024 10:00:00 UTC"},
+		{name: "openssl enddate", actual: "Mar  1 10:00:00 2024 GMT", criteria: "<", expected: "2024-03-01T10:00:01Z"},
+		{name: "invalid actual", actual: "yesterday", criteria: "<", expected: "now", wantErr: true},
+		{name: "invalid expected", actual: hourAgo, criteria: "<", expected: "01/03/2024", wantErr: true},
+		{name: "invalid offset", actual: hourAgo, criteria: "<", expected: "now+abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FirstValue(tt.actual).SecondValue(tt.expected).Criteria(tt.criteria).ProbeName("cmd-probe").Compare("timestamp", cerrors.FailureTypeCmdProbe)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, cerrors.FailureTypeCmdProbe, cerrors.GetErrorType(err))
+		})
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "now", want: now},
+		{value: "now-5m", want: now.Add(-5 * time.Minute)},
+		{value: "now+30d", want: now.Add(30 * 24 * time.Hour)},
+		{value: "now+90", want: now.Add(90 * time.Second)},
+		{value: strconv.FormatInt(now.Unix(), 10), want: now},
+		{value: "2024-03-01T10:00:00.5Z", want: now.Add(500 * time.Millisecond)},
+		{value: "Fri, 01 Mar 2024 10:00:00 +0000", want: now},
+		{value: "2024-03-01 10:00:00", want: now},
+		{value: "Mar  1 10:00:00 2024 UTC", want: now},
+		{value: "now+abc", wantErr: true},
+		{value: "2024-03-01", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseTimestamp(tt.value, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "expected %v, got %v", tt.want, got)
+		})
+	}
+}
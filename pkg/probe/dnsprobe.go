@@ -0,0 +1,591 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/math"
+	cmp "github.com/figwood/litmus-go/pkg/probe/comparator"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/figwood/litmus-go/pkg/utils/retry"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/miekg/dns"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// prepareDNSProbe contains the steps to prepare the dns probe
+// dns probe can be used to add the probe which will resolve the given names and check the rcode, records and latency
+func prepareDNSProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+
+	if getProbeInputs(probe.Name, resultDetails.ProbeDetails).DNSProbeInputs == nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "dnsProbe/inputs are required for the dns probe"}
+	}
+
+	switch strings.ToLower(phase) {
+	case "prechaos":
+		if err := preChaosDNSProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "postchaos":
+		if err := postChaosDNSProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "duringchaos":
+		onChaosDNSProbe(probe, resultDetails, clients, chaosDetails)
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("phase '%s' not supported in the dns probe", phase)}
+	}
+	return nil
+}
+
+// triggerDNSProbe run the dns probe for all the names
+func triggerDNSProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails) (err error) {
+	defer observeProbe(probe).end(&err)
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := *getProbeInputs(probe.Name, resultDetails.ProbeDetails).DNSProbeInputs
+
+	if len(inputs.Names) == 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "[Probe]: At least one name is required"}
+	}
+	queryType, ok := dns.StringToType[getDNSQueryType(inputs.QueryType)]
+	if !ok || (queryType != dns.TypeA && queryType != dns.TypeAAAA) {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("query type '%s' not supported in the dns probe", inputs.QueryType)}
+	}
+	expectedRcode, ok := dns.StringToRcode[strings.ToUpper(getDNSRcode(inputs.Rcode))]
+	if !ok {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("rcode '%s' not supported in the dns probe", inputs.Rcode)}
+	}
+
+	// It parses the templated names and return normal string
+	// if name doesn't have template, it will return the same name
+	names := make([]string, len(inputs.Names))
+	for index := range inputs.Names {
+		if names[index], err = parseCommand(strings.TrimSpace(inputs.Names[index]), resultDetails); err != nil {
+			return err
+		}
+	}
+
+	// deriving the pid of the target container, the queries are sent from its network namespace
+	pid := 0
+	if inputs.Target != nil {
+		if pid, err = getDNSTargetPID(inputs.Target, clients, probe.Name); err != nil {
+			return stacktrace.Propagate(err, "could not get the pid of the dns probe target")
+		}
+	}
+
+	resolvConf := "/etc/resolv.conf"
+	if pid != 0 {
+		resolvConf = fmt.Sprintf("/proc/%d/root/etc/resolv.conf", pid)
+	}
+	resolver, searchNames, err := getDNSResolver(inputs.Resolver, resolvConf, probe.Name)
+	if err != nil {
+		return err
+	}
+
+	client := &dns.Client{Net: getDNSTransport(inputs.Transport), Timeout: probeTimeout.ProbeTimeout}
+
+	var description string
+
+	// it will retry for some retry count, in each iteration of try it contains following things
+	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
+	// for a timeout, it will resolve all the names, if it fails wait for the interval and again resolve the names until timeout expires
+	if err := retry.Times(uint(getAttempts(probe.RunProperties.Attempt, probe.RunProperties.Retry))).
+		Timeout(probeTimeout.ProbeTimeout).
+		Wait(probeTimeout.Interval).
+		Context(types.AbortContext()).
+		TryWithTimeout(func(attempt uint) error {
+			rc := getAndIncrementRunCount(resultDetails, probe.Name)
+			var results []string
+			for _, name := range names {
+				var resp *dns.Msg
+				var latency float64
+				if err := runInNetNS(pid, func() error {
+					var err error
+					resp, latency, err = resolveDNSName(client, resolver, searchNames(name), queryType)
+					return err
+				}); err != nil {
+					log.Errorf("The %v dns probe has Failed for %v name, err: %v", probe.Name, name, err)
+					return cerrors.Error{ErrorCode: cerrors.FailureTypeDNSProbe, Target: fmt.Sprintf("{name: %v, host: %v}", probe.Name, name), Reason: fmt.Sprintf("unable to resolve using %s resolver, err: %v", resolver, err)}
+				}
+
+				if resp.Rcode != expectedRcode {
+					log.Errorf("The %v dns probe has Failed for %v name, rcode: %v", probe.Name, name, dns.RcodeToString[resp.Rcode])
+					return cerrors.Error{ErrorCode: cerrors.FailureTypeDNSProbe, Target: fmt.Sprintf("{name: %v, host: %v}", probe.Name, name), Reason: fmt.Sprintf("Actual rcode: %s. Expected rcode: %s", dns.RcodeToString[resp.Rcode], dns.RcodeToString[expectedRcode])}
+				}
+
+				records := getDNSRecords(resp)
+				if inputs.Records != nil {
+					// comparing the sorted and space separated records with the expected criteria
+					if err := cmp.RunCount(rc).
+						FirstValue(strings.Join(records, " ")).
+						SecondValue(inputs.Records.Value).
+						Criteria(inputs.Records.Criteria).
+						ProbeName(probe.Name).
+						ProbeVerbosity(probe.RunProperties.Verbosity).
+						CompareString(cerrors.FailureTypeDNSProbe); err != nil {
+						log.Errorf("The %v dns probe records check has Failed for %v name, err: %v", probe.Name, name, err)
+						return err
+					}
+				}
+				if inputs.Latency != nil {
+					// comparing the lookup latency(in ms) with the expected criteria
+					if err := cmp.RunCount(rc).
+						FirstValue(fmt.Sprintf("%.3f", latency)).
+						SecondValue(inputs.Latency.Value).
+						Criteria(inputs.Latency.Criteria).
+						ProbeName(probe.Name).
+						ProbeVerbosity(probe.RunProperties.Verbosity).
+						CompareFloat(cerrors.FailureTypeDNSProbe); err != nil {
+						log.Errorf("The %v dns probe latency check has Failed for %v name, err: %v", probe.Name, name, err)
+						return err
+					}
+				}
+				results = append(results, fmt.Sprintf("%s: %s [%s] %.3fms", name, dns.RcodeToString[resp.Rcode], strings.Join(records, ","), latency))
+			}
+			description = fmt.Sprintf("All the names are resolved as expected using %s resolver. Result: [%s]", resolver, strings.Join(results, ", "))
+			return nil
+		}); err != nil {
+		return checkProbeTimeoutError(probe.Name, cerrors.FailureTypeDNSProbe, err)
+	}
+	setProbeDescription(resultDetails, probe, description)
+	return nil
+}
+
+// resolveDNSName query the resolver for all the candidate names of the given name
+// it stops at the first candidate which doesn't respond with NXDOMAIN and returns its response and latency in milliseconds
+func resolveDNSName(client *dns.Client, resolver string, candidates []string, queryType uint16) (*dns.Msg, float64, error) {
+	var resp *dns.Msg
+	var latency float64
+	for _, candidate := range candidates {
+		msg := new(dns.Msg)
+		msg.SetQuestion(candidate, queryType)
+		startTime := time.Now()
+		r, _, err := client.Exchange(msg, resolver)
+		if err != nil {
+			return nil, 0, err
+		}
+		resp, latency = r, float64(time.Since(startTime).Microseconds())/1000
+		if resp.Rcode != dns.RcodeNameError {
+			break
+		}
+	}
+	return resp, latency, nil
+}
+
+// getDNSRecords returns the sorted A/AAAA records present inside the answer section
+func getDNSRecords(resp *dns.Msg) []string {
+	records := []string{}
+	for _, answer := range resp.Answer {
+		switch record := answer.(type) {
+		case *dns.A:
+			records = append(records, record.A.String())
+		case *dns.AAAA:
+			records = append(records, record.AAAA.String())
+		}
+	}
+	sort.Strings(records)
+	return records
+}
+
+// getDNSResolver returns the address of the resolver and the function to derive the candidate names
+// it uses the nameserver and search domains from the resolv.conf if resolver is not provided
+func getDNSResolver(resolver, resolvConf, probeName string) (string, func(string) []string, error) {
+	fqdn := func(name string) []string { return []string{dns.Fqdn(name)} }
+	if resolver != "" {
+		if _, _, err := net.SplitHostPort(resolver); err != nil {
+			resolver = net.JoinHostPort(resolver, "53")
+		}
+		return resolver, fqdn, nil
+	}
+
+	conf, err := dns.ClientConfigFromFile(resolvConf)
+	if err != nil {
+		return "", nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to read the %s, err: %v", resolvConf, err)}
+	}
+	if len(conf.Servers) == 0 {
+		return "", nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("no nameserver found inside the %s", resolvConf)}
+	}
+	return net.JoinHostPort(conf.Servers[0], conf.Port), conf.NameList, nil
+}
+
+// getDNSTargetPID returns the pid of the target container
+// it looks for the process whose cgroup contains the container id, so it doesn't depend upon the container runtime
+func getDNSTargetPID(target *types.DNSProbeTarget, clients clients.ClientSets, probeName string) (int, error) {
+	pod, err := clients.KubeClient.CoreV1().Pods(target.Namespace).Get(context.Background(), target.PodName, v1.GetOptions{})
+	if err != nil {
+		return 0, cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v, podName: %s, namespace: %s}", probeName, target.PodName, target.Namespace), Reason: err.Error()}
+	}
+
+	container := target.Container
+	if container == "" && len(pod.Spec.Containers) != 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	var containerID string
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container && status.State.Running != nil {
+			if containerID, err = parseContainerID(status.ContainerID); err != nil {
+				return 0, cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v, podName: %s, namespace: %s, container: %s}", probeName, target.PodName, target.Namespace, container), Reason: err.Error()}
+			}
+		}
+	}
+	if containerID == "" {
+		return 0, cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v, podName: %s, namespace: %s, container: %s}", probeName, target.PodName, target.Namespace, container), Reason: "no running target container found"}
+	}
+
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0, cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to list the processes, err: %v", err)}
+	}
+	for _, proc := range procs {
+		pid, err := strconv.Atoi(proc.Name())
+		if err != nil {
+			continue
+		}
+		cgroup, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+		if err != nil {
+			continue
+		}
+		if strings.Contains(string(cgroup), containerID) {
+			log.Infof("[Info]: Container ID=%s has process PID=%d", containerID, pid)
+			return pid, nil
+		}
+	}
+	return 0, cerrors.Error{ErrorCode: cerrors.ErrorTypeDNSProbe, Target: fmt.Sprintf("{name: %v, podName: %s, namespace: %s, container: %s}", probeName, target.PodName, target.Namespace, container), Reason: "unable to find the process of the target container, the experiment pod should run with hostPID"}
+}
+
+// parseContainerID returns the container id without the runtime prefix
+// container id is present in the form of <runtime>://<container-id>, it is empty until the container is started
+func parseContainerID(containerID string) (string, error) {
+	_, id, ok := strings.Cut(containerID, "://")
+	if !ok || strings.TrimSpace(id) == "" {
+		return "", fmt.Errorf("invalid container id '%s' of the target container, expected <runtime>://<container-id>", containerID)
+	}
+	return id, nil
+}
+
+// runInNetNS runs the given function inside the network namespace of the given pid
+// it runs inside the current network namespace if pid is not provided
+func runInNetNS(pid int, fn func() error) error {
+	if pid == 0 {
+		return fn()
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		// the thread is never unlocked, so it gets terminated with the goroutine instead of
+		// returning to the pool with the target network namespace
+		runtime.LockOSThread()
+
+		netNS, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", pid))
+		if err != nil {
+			errChan <- err
+			return
+		}
+		defer netNS.Close()
+
+		if err := unix.Setns(int(netNS.Fd()), unix.CLONE_NEWNET); err != nil {
+			errChan <- fmt.Errorf("unable to enter the network namespace of pid %d, err: %v", pid, err)
+			return
+		}
+		errChan <- fn()
+	}()
+	return <-errChan
+}
+
+// getDNSQueryType returns the query type of the dns probe, default query type is A
+func getDNSQueryType(queryType string) string {
+	if queryType == "" {
+		return "A"
+	}
+	return strings.ToUpper(queryType)
+}
+
+// getDNSRcode returns the expected rcode of the dns probe, default rcode is NOERROR
+func getDNSRcode(rcode string) string {
+	if rcode == "" {
+		return "NOERROR"
+	}
+	return rcode
+}
+
+// getDNSTransport returns the transport protocol of the dns probe, default transport is udp
+func getDNSTransport(transport string) string {
+	if strings.ToLower(transport) == "tcp" {
+		return "tcp"
+	}
+	return "udp"
+}
+
+// triggerContinuousDNSProbe trigger the continuous dns probes
+func triggerContinuousDNSProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+	var isExperimentFailed bool
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+	}
+
+	// it triggers the dns probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+
+loop:
+	for {
+		select {
+		case <-chaosDetails.ProbeContext.Ctx.Done():
+			log.Infof("Stopping %s continuous Probe", probe.Name)
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].HasProbeCompleted = true
+				}
+			}
+			break loop
+		default:
+			err = triggerDNSProbe(probe, clients, chaosresult)
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+						chaosresult.ProbeDetails[index].Status.Description = getDescription(err)
+						log.Errorf("The %v dns probe has been Failed, err: %v", probe.Name, err)
+						isExperimentFailed = true
+						break loop
+					}
+				}
+			}
+			// waiting for the probe polling interval
+			time.Sleep(probeTimeout.ProbePollingInterval)
+		}
+	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
+	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+	// and failed the experiment in the end
+	if isExperimentFailed && probe.RunProperties.StopOnFailure {
+		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("Unable to patch chaosengine to stop, err: %v", err)
+		}
+	}
+}
+
+// preChaosDNSProbe trigger the dns probe for prechaos phase
+func preChaosDNSProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).DNSProbeInputs
+
+	switch probe.Mode {
+	case "SOT", "Edge":
+
+		//DISPLAY THE DNS PROBE INFO
+		log.InfoWithValues("[Probe]: The dns probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Names":          inputs.Names,
+			"Query Type":     getDNSQueryType(inputs.QueryType),
+			"Resolver":       inputs.Resolver,
+			"Target":         inputs.Target,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		// waiting for initial delay
+		if probeTimeout.InitialDelay != 0 {
+			log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+			time.Sleep(probeTimeout.InitialDelay)
+		}
+		// trigger the dns probe
+		if err = triggerDNSProbe(probe, clients, resultDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeDNSProbe {
+			return err
+		}
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PreChaos"); err != nil {
+			return err
+		}
+	case "Continuous":
+
+		//DISPLAY THE DNS PROBE INFO
+		log.InfoWithValues("[Probe]: The dns probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Names":          inputs.Names,
+			"Query Type":     getDNSQueryType(inputs.QueryType),
+			"Resolver":       inputs.Resolver,
+			"Target":         inputs.Target,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		go triggerContinuousDNSProbe(probe, clients, resultDetails, chaosDetails)
+
+	}
+	return nil
+}
+
+// postChaosDNSProbe trigger the dns probe for postchaos phase
+func postChaosDNSProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).DNSProbeInputs
+
+	switch probe.Mode {
+	case "EOT", "Edge":
+
+		//DISPLAY THE DNS PROBE INFO
+		log.InfoWithValues("[Probe]: The dns probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Names":          inputs.Names,
+			"Query Type":     getDNSQueryType(inputs.QueryType),
+			"Resolver":       inputs.Resolver,
+			"Target":         inputs.Target,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PostChaos",
+		})
+
+		// waiting for initial delay
+		if probeTimeout.InitialDelay != 0 {
+			log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+			time.Sleep(probeTimeout.InitialDelay)
+		}
+
+		// trigger the dns probe
+		if err = triggerDNSProbe(probe, clients, resultDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeDNSProbe {
+			return err
+		}
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	case "Continuous", "OnChaos":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		if err = checkForErrorInContinuousProbe(resultDetails, probe.Name, chaosDetails.Delay, chaosDetails.Timeout); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeDNSProbe && cerrors.GetErrorType(err) != cerrors.FailureTypeProbeTimeout {
+			return err
+		}
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// triggerOnChaosDNSProbe trigger the onchaos dns probes
+func triggerOnChaosDNSProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+
+	var isExperimentFailed bool
+	duration := chaosDetails.ChaosDuration
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+		duration = math.Maximum(0, duration-int(probeTimeout.InitialDelay.Seconds()))
+	}
+
+	endTime := time.After(time.Duration(duration) * time.Second)
+
+	// it trigger the dns probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		select {
+		case <-endTime:
+			log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+			endTime = nil
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].HasProbeCompleted = true
+				}
+			}
+			break loop
+		default:
+			err = triggerDNSProbe(probe, clients, chaosresult)
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+						chaosresult.ProbeDetails[index].Status.Description = getDescription(err)
+						log.Errorf("The %v dns probe has been Failed, err: %v", probe.Name, err)
+						isExperimentFailed = true
+						break loop
+					}
+				}
+			}
+
+			select {
+			case <-chaosDetails.ProbeContext.Ctx.Done():
+				log.Infof("Stopping %s continuous Probe", probe.Name)
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+					}
+				}
+				break loop
+			default:
+				// waiting for the probe polling interval
+				time.Sleep(probeTimeout.ProbePollingInterval)
+			}
+		}
+	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
+	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+	// and failed the experiment in the end
+	if isExperimentFailed && probe.RunProperties.StopOnFailure {
+		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("unable to patch chaosengine to stop, err: %v", err)
+		}
+	}
+}
+
+// onChaosDNSProbe trigger the dns probe for DuringChaos phase
+func onChaosDNSProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).DNSProbeInputs
+
+	switch probe.Mode {
+	case "OnChaos":
+
+		//DISPLAY THE DNS PROBE INFO
+		log.InfoWithValues("[Probe]: The dns probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Names":          inputs.Names,
+			"Query Type":     getDNSQueryType(inputs.QueryType),
+			"Resolver":       inputs.Resolver,
+			"Target":         inputs.Target,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+		go triggerOnChaosDNSProbe(probe, clients, resultDetails, chaosDetails)
+	}
+
+}
@@ -0,0 +1,34 @@
+package probe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContainerID(t *testing.T) {
+	tests := []struct {
+		containerID string
+		want        string
+		wantErr     bool
+	}{
+		{containerID: "containerd://4f9c1e2a", want: "4f9c1e2a"},
+		{containerID: "docker://0b1d2c3e", want: "0b1d2c3e"},
+		{containerID: "cri-o://5a6b7c8d", want: "5a6b7c8d"},
+		{containerID: "", wantErr: true},
+		{containerID: "4f9c1e2a", wantErr: true},
+		{containerID: "containerd://", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.containerID, func(t *testing.T) {
+			got, err := parseContainerID(tt.containerID)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
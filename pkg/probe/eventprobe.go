@@ -0,0 +1,448 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/math"
+	cmp "github.com/figwood/litmus-go/pkg/probe/comparator"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// prepareEventProbe contains the steps to prepare the event probe
+// event probe can be used to add the probe which will watch the kubernetes events during chaos and check the count of the matched events
+func prepareEventProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+
+	if getProbeInputs(probe.Name, resultDetails.ProbeDetails).EventProbeInputs == nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeEventProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "eventProbe/inputs are required for the event probe"}
+	}
+
+	// the events are watched over the chaos window, so the probe is supported only in continuous and onchaos modes
+	switch strings.ToLower(probe.Mode) {
+	case "continuous", "onchaos":
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeEventProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("mode '%s' not supported in the event probe", probe.Mode)}
+	}
+
+	switch strings.ToLower(phase) {
+	case "prechaos":
+		preChaosEventProbe(probe, resultDetails, clients, chaosDetails)
+	case "postchaos":
+		if err := postChaosEventProbe(probe, resultDetails, chaosDetails.Delay, chaosDetails.Timeout); err != nil {
+			return err
+		}
+	case "duringchaos":
+		onChaosEventProbe(probe, resultDetails, clients, chaosDetails)
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeEventProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("phase '%s' not supported in the event probe", phase)}
+	}
+	return nil
+}
+
+// eventRecord contains the count of the event at the start of the chaos and at the latest update
+type eventRecord struct {
+	baseline int
+	current  int
+	summary  string
+}
+
+// eventWatcher watches the events of the namespaces and records the occurrences of the matching events
+type eventWatcher struct {
+	probeName    string
+	clients      clients.ClientSets
+	chaosDetails *types.ChaosDetails
+	inputs       *types.EventProbeInputs
+	eventType    string
+
+	mutex   sync.Mutex
+	events  map[k8stypes.UID]*eventRecord
+	helpers map[string]bool
+	err     error
+}
+
+// newEventWatcher returns the watcher for the given inputs
+func newEventWatcher(probeName string, inputs *types.EventProbeInputs, clients clients.ClientSets, chaosDetails *types.ChaosDetails) *eventWatcher {
+	eventType := inputs.Type
+	if eventType == "" {
+		eventType = corev1.EventTypeWarning
+	}
+	return &eventWatcher{
+		probeName:    probeName,
+		clients:      clients,
+		chaosDetails: chaosDetails,
+		inputs:       inputs,
+		eventType:    eventType,
+		events:       map[k8stypes.UID]*eventRecord{},
+		helpers:      map[string]bool{},
+	}
+}
+
+// watch records the matching events of all the namespaces until the context is done
+func (w *eventWatcher) watch(ctx context.Context, namespaces []string) error {
+	var watchers sync.WaitGroup
+	for _, namespace := range namespaces {
+		watchers.Add(1)
+		go func(namespace string) {
+			defer watchers.Done()
+			if err := w.watchNamespace(ctx, namespace); err != nil {
+				w.mutex.Lock()
+				w.err = err
+				w.mutex.Unlock()
+			}
+		}(namespace)
+	}
+	watchers.Wait()
+	return w.err
+}
+
+// watchNamespace records the matching events of the namespace
+// the events present before the watch are used as baseline, only their later occurrences are counted
+func (w *eventWatcher) watchNamespace(ctx context.Context, namespace string) error {
+	listOptions := v1.ListOptions{FieldSelector: "type=" + w.eventType}
+
+	// only the events of the first list are used as baseline, the relisted events are occurred during the watch
+	resourceVersion, isBaseline := "", true
+	for ctx.Err() == nil {
+		if resourceVersion == "" {
+			events, err := w.clients.KubeClient.CoreV1().Events(namespace).List(ctx, listOptions)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeEventProbe, Target: fmt.Sprintf("{name: %v}", w.probeName), Reason: fmt.Sprintf("unable to list the events in %s namespace, err: %v", namespace, err)}
+			}
+			for index := range events.Items {
+				w.record(ctx, &events.Items[index], isBaseline)
+			}
+			resourceVersion, isBaseline = events.ResourceVersion, false
+		}
+
+		watchOptions := listOptions
+		watchOptions.ResourceVersion = resourceVersion
+		watcher, err := w.clients.KubeClient.CoreV1().Events(namespace).Watch(ctx, watchOptions)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.Warnf("unable to watch the events of %v event probe, err: %v", w.probeName, err)
+			resourceVersion = ""
+			time.Sleep(time.Second)
+			continue
+		}
+		resourceVersion = w.consumeEvents(ctx, watcher, resourceVersion)
+	}
+	return nil
+}
+
+// consumeEvents records the events received from the watch
+// it returns the last observed resource version to resume the watch, which is empty if the events need to be listed again
+func (w *eventWatcher) consumeEvents(ctx context.Context, watcher watch.Interface, resourceVersion string) string {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion
+			}
+			if event.Type == watch.Error {
+				return ""
+			}
+			if e, ok := event.Object.(*corev1.Event); ok {
+				resourceVersion = e.ResourceVersion
+				if event.Type == watch.Added || event.Type == watch.Modified {
+					w.record(ctx, e, false)
+				}
+			}
+		}
+	}
+}
+
+// record updates the count of the event, if it matches the kinds and reasons
+// the events of the helper pods of the experiment are ignored
+func (w *eventWatcher) record(ctx context.Context, event *corev1.Event, isBaseline bool) {
+	if !containsFold(w.inputs.Kinds, event.InvolvedObject.Kind) || !containsFold(w.inputs.Reasons, event.Reason) {
+		return
+	}
+	if event.InvolvedObject.Kind == "Pod" && w.isHelperPod(ctx, event.InvolvedObject.Namespace, event.InvolvedObject.Name) {
+		return
+	}
+
+	count := math.Maximum(1, int(event.Count))
+	if event.Series != nil {
+		count = math.Maximum(count, int(event.Series.Count))
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	record, ok := w.events[event.UID]
+	if !ok {
+		record = &eventRecord{}
+		w.events[event.UID] = record
+		if isBaseline {
+			record.baseline = count
+		}
+	}
+	record.current = count
+	record.summary = fmt.Sprintf("%s/%s/%s: %s: %s", event.InvolvedObject.Namespace, event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Reason, strings.TrimSpace(event.Message))
+}
+
+// isHelperPod checks whether the pod is the helper pod of the experiment
+// the helper pods already deleted are identified by their name, which is generated from the same prefix as the labels
+func (w *eventWatcher) isHelperPod(ctx context.Context, namespace, name string) bool {
+	if namespace != w.chaosDetails.ChaosNamespace {
+		return false
+	}
+	prefix := w.chaosDetails.ExperimentName + "-helper-"
+
+	w.mutex.Lock()
+	isHelper, ok := w.helpers[name]
+	w.mutex.Unlock()
+	if ok {
+		return isHelper
+	}
+
+	pod, err := w.clients.KubeClient.CoreV1().Pods(namespace).Get(ctx, name, v1.GetOptions{})
+	switch {
+	case err == nil:
+		isHelper = hasHelperLabels(pod, w.chaosDetails)
+	case k8serrors.IsNotFound(err):
+		isHelper = strings.HasPrefix(name, prefix)
+	default:
+		return strings.HasPrefix(name, prefix)
+	}
+
+	w.mutex.Lock()
+	w.helpers[name] = isHelper
+	w.mutex.Unlock()
+	return isHelper
+}
+
+// hasHelperLabels checks whether the pod carries the labels of the experiment helper pods
+// the helper pods carry the labels from common.GetHelperLabels, i.e. app: <experiment-name>-helper-<run-id>
+func hasHelperLabels(pod *corev1.Pod, chaosDetails *types.ChaosDetails) bool {
+	return pod.Namespace == chaosDetails.ChaosNamespace && strings.HasPrefix(pod.Labels["app"], chaosDetails.ExperimentName+"-helper-")
+}
+
+// evaluate verify the count of the matched events against the threshold
+// it returns the summary of the matched events as description
+func (w *eventWatcher) evaluate(probe v1alpha1.ProbeAttributes) (string, []string, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	maxArtifacts := w.inputs.MaxArtifacts
+	if maxArtifacts == 0 {
+		maxArtifacts = 10
+	}
+
+	total := 0
+	artifacts := []string{}
+	for _, record := range w.events {
+		occurrences := record.current - record.baseline
+		if occurrences <= 0 {
+			continue
+		}
+		total += occurrences
+		artifacts = append(artifacts, fmt.Sprintf("%s (x%d)", record.summary, occurrences))
+	}
+	sort.Strings(artifacts)
+	if len(artifacts) > maxArtifacts {
+		artifacts = artifacts[:maxArtifacts]
+	}
+
+	count := v1alpha1.ComparatorInfo{Criteria: "<=", Value: "0"}
+	if w.inputs.Count != nil {
+		count = *w.inputs.Count
+	}
+	if err := cmp.RunCount(1).
+		FirstValue(strconv.Itoa(total)).
+		SecondValue(count.Value).
+		Criteria(count.Criteria).
+		ProbeName(probe.Name).
+		ProbeVerbosity(probe.RunProperties.Verbosity).
+		CompareInt(cerrors.FailureTypeEventProbe); err != nil {
+		return "", artifacts, cerrors.Error{ErrorCode: cerrors.FailureTypeEventProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("The %s events occurred %d times during chaos. %s", w.eventType, total, getDescription(err))}
+	}
+	return fmt.Sprintf("The %s events occurred %d times during chaos. Expected count: %s %s", w.eventType, total, count.Criteria, count.Value), artifacts, nil
+}
+
+// containsFold checks whether the value is present inside the list, ignoring the case
+// it returns true for the empty list, as the empty list matches all the values
+func containsFold(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// getTargetNamespaces returns the given namespaces
+// it derives the namespaces from the chaos targets(appinfo), if namespaces are not provided
+func getTargetNamespaces(namespaces []string, chaosDetails *types.ChaosDetails) []string {
+	if len(namespaces) != 0 {
+		return namespaces
+	}
+
+	for _, app := range chaosDetails.AppDetail {
+		if app.Namespace != "" && (len(namespaces) == 0 || !containsFold(namespaces, app.Namespace)) {
+			namespaces = append(namespaces, app.Namespace)
+		}
+	}
+	return namespaces
+}
+
+// runEventProbe watches the events until the context is done and evaluate the matched events
+// it records the verdict along with the matched events inside the probe details
+func runEventProbe(ctx context.Context, probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	inputs := getProbeInputs(probe.Name, chaosresult.ProbeDetails).EventProbeInputs
+
+	description, artifacts, err := watchEvents(ctx, probe, inputs, clients, chaosDetails)
+	for index := range chaosresult.ProbeDetails {
+		if chaosresult.ProbeDetails[index].Name == probe.Name {
+			chaosresult.ProbeDetails[index].Artifacts = artifacts
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+				description = getDescription(err)
+				log.Errorf("The %v event probe has been Failed, err: %v", probe.Name, err)
+			}
+			chaosresult.ProbeDetails[index].Status.Description = description
+			chaosresult.ProbeDetails[index].HasProbeCompleted = true
+		}
+	}
+	for _, artifact := range artifacts {
+		log.Infof("[Probe]: The %v event probe matched the event, %v", probe.Name, artifact)
+	}
+}
+
+// watchEvents watches the events of the namespaces until the context is done
+// it returns the description along with the matched events
+func watchEvents(ctx context.Context, probe v1alpha1.ProbeAttributes, inputs *types.EventProbeInputs, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (string, []string, error) {
+	namespaces := getTargetNamespaces(inputs.Namespaces, chaosDetails)
+	if len(namespaces) == 0 {
+		return "", nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeEventProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "[Probe]: Any one of namespaces or chaos targets is required to watch the events"}
+	}
+
+	watcher := newEventWatcher(probe.Name, inputs, clients, chaosDetails)
+	if err := watcher.watch(ctx, namespaces); err != nil {
+		return "", nil, err
+	}
+	return watcher.evaluate(probe)
+}
+
+// triggerContinuousEventProbe watches the events for the entire duration of chaos
+func triggerContinuousEventProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+	}
+
+	runEventProbe(chaosDetails.ProbeContext.Ctx, probe, clients, chaosresult, chaosDetails)
+	log.Infof("Stopping %s continuous Probe", probe.Name)
+}
+
+// triggerOnChaosEventProbe watches the events for the chaos duration
+func triggerOnChaosEventProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+
+	duration := chaosDetails.ChaosDuration
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+		duration = math.Maximum(0, duration-int(probeTimeout.InitialDelay.Seconds()))
+	}
+
+	ctx, cancel := context.WithTimeout(chaosDetails.ProbeContext.Ctx, time.Duration(duration)*time.Second)
+	defer cancel()
+
+	runEventProbe(ctx, probe, clients, chaosresult, chaosDetails)
+	log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+}
+
+// preChaosEventProbe trigger the event probe for prechaos phase
+func preChaosEventProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).EventProbeInputs
+
+	switch strings.ToLower(probe.Mode) {
+	case "continuous":
+
+		//DISPLAY THE EVENT PROBE INFO
+		log.InfoWithValues("[Probe]: The event probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Namespaces":     inputs.Namespaces,
+			"Kinds":          inputs.Kinds,
+			"Reasons":        inputs.Reasons,
+			"Count":          inputs.Count,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		go triggerContinuousEventProbe(probe, clients, resultDetails, chaosDetails)
+	}
+}
+
+// postChaosEventProbe trigger the event probe for postchaos phase
+func postChaosEventProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, delay int, timeout int) error {
+	switch strings.ToLower(probe.Mode) {
+	case "continuous", "onchaos":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		if err = checkForErrorInContinuousProbe(resultDetails, probe.Name, delay, timeout); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeEventProbe && cerrors.GetErrorType(err) != cerrors.FailureTypeProbeTimeout {
+			return err
+		}
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onChaosEventProbe trigger the event probe for DuringChaos phase
+func onChaosEventProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).EventProbeInputs
+
+	switch strings.ToLower(probe.Mode) {
+	case "onchaos":
+
+		//DISPLAY THE EVENT PROBE INFO
+		log.InfoWithValues("[Probe]: The event probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Namespaces":     inputs.Namespaces,
+			"Kinds":          inputs.Kinds,
+			"Reasons":        inputs.Reasons,
+			"Count":          inputs.Count,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+		go triggerOnChaosEventProbe(probe, clients, resultDetails, chaosDetails)
+	}
+}
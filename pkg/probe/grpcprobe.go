@@ -0,0 +1,547 @@
+package probe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/math"
+	cmp "github.com/figwood/litmus-go/pkg/probe/comparator"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/figwood/litmus-go/pkg/utils/retry"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// prepareGRPCProbe contains the steps to prepare the grpc probe
+// grpc probe can be used to add the probe which will call the grpc health check or an unary method of the given server
+func prepareGRPCProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+
+	if getProbeInputs(probe.Name, resultDetails.ProbeDetails).GRPCProbeInputs == nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "grpcProbe/inputs are required for the grpc probe"}
+	}
+
+	switch strings.ToLower(phase) {
+	case "prechaos":
+		if err := preChaosGRPCProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "postchaos":
+		if err := postChaosGRPCProbe(probe, resultDetails, chaosDetails.Delay, chaosDetails.Timeout); err != nil {
+			return err
+		}
+	case "duringchaos":
+		onChaosGRPCProbe(probe, resultDetails, clients, chaosDetails)
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("phase '%s' not supported in the grpc probe", phase)}
+	}
+	return nil
+}
+
+// triggerGRPCProbe run the grpc probe
+func triggerGRPCProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) (err error) {
+	defer observeProbe(probe).end(&err)
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := *getProbeInputs(probe.Name, resultDetails.ProbeDetails).GRPCProbeInputs
+
+	// It parses the templated host and return normal string
+	// if host doesn't have template, it will return the same host
+	inputs.Host, err = parseCommand(inputs.Host, resultDetails)
+	if err != nil {
+		return err
+	}
+
+	dialOption, err := getGRPCTransportCredentials(inputs, probe.Name)
+	if err != nil {
+		return err
+	}
+
+	var description string
+
+	// it will retry for some retry count, in each iteration of try it contains following things
+	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
+	// for a timeout, it will run the request, if it fails wait for the interval and again execute the request until timeout expires
+	if err := retry.Times(uint(getAttempts(probe.RunProperties.Attempt, probe.RunProperties.Retry))).
+		Timeout(probeTimeout.ProbeTimeout).
+		Wait(probeTimeout.Interval).
+		Context(types.AbortContext()).
+		TryWithTimeout(func(attempt uint) error {
+			ctx, cancel := getGRPCContext(inputs, probeTimeout.ProbeTimeout)
+			defer cancel()
+
+			conn, err := grpc.DialContext(ctx, inputs.Host, dialOption)
+			if err != nil {
+				return cerrors.Error{ErrorCode: cerrors.FailureTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to connect to %s, err: %v", inputs.Host, err)}
+			}
+			defer conn.Close()
+
+			rc := getAndIncrementRunCount(resultDetails, probe.Name)
+
+			if inputs.Method == nil {
+				if description, err = grpcHealthCheck(ctx, conn, inputs, probe.Name); err != nil {
+					log.Errorf("The %v grpc probe health check has Failed, err: %v", probe.Name, err)
+					return err
+				}
+				return nil
+			}
+			if description, err = grpcInvokeMethod(ctx, conn, inputs, probe, rc); err != nil {
+				log.Errorf("The %v grpc probe method call has Failed, err: %v", probe.Name, err)
+				return err
+			}
+			return nil
+		}); err != nil {
+		return checkProbeTimeoutError(probe.Name, cerrors.FailureTypeGRPCProbe, err)
+	}
+	setProbeDescription(resultDetails, probe, description)
+	return nil
+}
+
+// getGRPCTransportCredentials returns the transport credentials for the grpc connection
+// it uses the plaintext connection if tls is not provided
+func getGRPCTransportCredentials(inputs types.GRPCProbeInputs, probeName string) (grpc.DialOption, error) {
+	if inputs.TLS == nil {
+		return grpc.WithInsecure(), nil
+	}
+	tlsConfig, err := getTLSConfig(inputs.TLS, probeName, cerrors.ErrorTypeGRPCProbe)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
+}
+
+// getGRPCContext returns the context for the grpc request, it contains the probe timeout and the metadata
+func getGRPCContext(inputs types.GRPCProbeInputs, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx := context.Background()
+	if len(inputs.Metadata) != 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(inputs.Metadata))
+	}
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// grpcHealthCheck calls the grpc.health.v1.Health/Check method and verify that the service is serving
+func grpcHealthCheck(ctx context.Context, conn *grpc.ClientConn, inputs types.GRPCProbeInputs, probeName string) (string, error) {
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: inputs.Service})
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.FailureTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("health check request to %s failed with code '%s', err: %s", inputs.Host, status.Code(err), status.Convert(err).Message())}
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return "", cerrors.Error{ErrorCode: cerrors.FailureTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("Actual health status: '%s'. Expected health status: '%s'", resp.Status, healthpb.HealthCheckResponse_SERVING)}
+	}
+	return fmt.Sprintf("The grpc server %s did respond with correct health status. Actual status: '%s'", inputs.Host, resp.Status), nil
+}
+
+// grpcInvokeMethod calls the given unary method with the json request and validate the json response
+// the request and response messages are resolved via the grpc server reflection
+func grpcInvokeMethod(ctx context.Context, conn *grpc.ClientConn, inputs types.GRPCProbeInputs, probe v1alpha1.ProbeAttributes, rc int) (string, error) {
+	methodName := strings.TrimPrefix(inputs.Method.Name, "/")
+	method, err := getGRPCMethodDescriptor(ctx, conn, methodName, probe.Name)
+	if err != nil {
+		return "", err
+	}
+
+	request := dynamicpb.NewMessage(method.Input())
+	if strings.TrimSpace(inputs.Method.Request) != "" {
+		if err := protojson.Unmarshal([]byte(inputs.Method.Request), request); err != nil {
+			return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to parse the request for method '%s', err: %v", methodName, err)}
+		}
+	}
+	response := dynamicpb.NewMessage(method.Output())
+
+	if err := conn.Invoke(ctx, "/"+methodName, request, response); err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.FailureTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("request to method '%s' failed with code '%s', err: %s", methodName, status.Code(err), status.Convert(err).Message())}
+	}
+
+	// protojson doesn't guarantee a stable output, compacting it before the comparison
+	data, err := protojson.Marshal(response)
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to parse the response of method '%s', err: %v", methodName, err)}
+	}
+	var out bytes.Buffer
+	if err := json.Compact(&out, data); err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to parse the response of method '%s', err: %v", methodName, err)}
+	}
+
+	if inputs.Method.Comparator == nil {
+		return fmt.Sprintf("The method '%s' did respond successfully", methodName), nil
+	}
+
+	compare := cmp.RunCount(rc).
+		FirstValue(out.String()).
+		SecondValue(inputs.Method.Comparator.Value).
+		Criteria(inputs.Method.Comparator.Criteria).
+		ProbeName(probe.Name).
+		ProbeVerbosity(probe.RunProperties.Verbosity)
+
+	comparatorType := inputs.Method.Comparator.Type
+	if comparatorType == "" {
+		comparatorType = "string"
+	}
+	if !cmp.IsSupportedType(comparatorType) {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("comparator type '%s' not supported in the grpc probe", inputs.Method.Comparator.Type)}
+	}
+	if err := compare.Compare(comparatorType, cerrors.FailureTypeGRPCProbe); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("The method '%s' did respond with expected response. Actual value: '%s'. Expected value: '%s'", methodName, out.String(), inputs.Method.Comparator.Value), nil
+}
+
+// getGRPCMethodDescriptor fetch the descriptor of the given method via the grpc server reflection
+func getGRPCMethodDescriptor(ctx context.Context, conn *grpc.ClientConn, methodName, probeName string) (protoreflect.MethodDescriptor, error) {
+	parts := strings.Split(methodName, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("method '%s' should be in the package.Service/Method format", methodName)}
+	}
+	serviceName := parts[0]
+
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.FailureTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to reach the server reflection, err: %s", status.Convert(err).Message())}
+	}
+	defer stream.CloseSend()
+
+	files := map[string]*descriptorpb.FileDescriptorProto{}
+	pending := []*rpb.ServerReflectionRequest{{MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: serviceName}}}
+
+	// fetch the file containing the service along with all of its dependencies
+	for len(pending) != 0 {
+		if err := stream.Send(pending[0]); err != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.FailureTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to query the server reflection, err: %s", status.Convert(err).Message())}
+		}
+		pending = pending[1:]
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.FailureTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to query the server reflection, err: %s", status.Convert(err).Message())}
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to resolve the service '%s' via server reflection, err: %s", serviceName, errResp.ErrorMessage)}
+		}
+		for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			file := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(raw, file); err != nil {
+				return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to parse the file descriptor, err: %v", err)}
+			}
+			files[file.GetName()] = file
+		}
+		if len(pending) == 0 {
+			for _, file := range files {
+				for _, dep := range file.GetDependency() {
+					if _, ok := files[dep]; !ok && !isPendingFile(pending, dep) {
+						pending = append(pending, &rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep}})
+					}
+				}
+			}
+		}
+	}
+
+	fileSet := &descriptorpb.FileDescriptorSet{}
+	for _, file := range files {
+		fileSet.File = append(fileSet.File, file)
+	}
+	registry, err := protodesc.NewFiles(fileSet)
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to build the descriptors of service '%s', err: %v", serviceName, err)}
+	}
+	desc, err := registry.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("service '%s' not found, err: %v", serviceName, err)}
+	}
+	service, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("'%s' is not a service", serviceName)}
+	}
+	method := service.Methods().ByName(protoreflect.Name(parts[1]))
+	if method == nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("method '%s' not found in service '%s'", parts[1], serviceName)}
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGRPCProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("method '%s' is not an unary method", methodName)}
+	}
+	return method, nil
+}
+
+// isPendingFile check whether the file is already queued for the reflection request
+func isPendingFile(pending []*rpb.ServerReflectionRequest, fileName string) bool {
+	for _, req := range pending {
+		if req.GetFileByFilename() == fileName {
+			return true
+		}
+	}
+	return false
+}
+
+// triggerContinuousGRPCProbe trigger the continuous grpc probes
+func triggerContinuousGRPCProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+	var isExperimentFailed bool
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+	}
+
+	// it triggers the grpc probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+
+loop:
+	for {
+		select {
+		case <-chaosDetails.ProbeContext.Ctx.Done():
+			log.Infof("Stopping %s continuous Probe", probe.Name)
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].HasProbeCompleted = true
+				}
+			}
+			break loop
+		default:
+			err = triggerGRPCProbe(probe, chaosresult)
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+						chaosresult.ProbeDetails[index].Status.Description = getDescription(err)
+						log.Errorf("The %v grpc probe has been Failed, err: %v", probe.Name, err)
+						isExperimentFailed = true
+						break loop
+					}
+				}
+			}
+			// waiting for the probe polling interval
+			time.Sleep(probeTimeout.ProbePollingInterval)
+		}
+	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
+	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+	// and failed the experiment in the end
+	if isExperimentFailed && probe.RunProperties.StopOnFailure {
+		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("Unable to patch chaosengine to stop, err: %v", err)
+		}
+	}
+}
+
+// preChaosGRPCProbe trigger the grpc probe for prechaos phase
+func preChaosGRPCProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).GRPCProbeInputs
+
+	switch probe.Mode {
+	case "SOT", "Edge":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Host":           inputs.Host,
+			"Service":        inputs.Service,
+			"Method":         inputs.Method,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		// waiting for initial delay
+		if probeTimeout.InitialDelay != 0 {
+			log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+			time.Sleep(probeTimeout.InitialDelay)
+		}
+		// trigger the grpc probe
+		if err = triggerGRPCProbe(probe, resultDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeGRPCProbe {
+			return err
+		}
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PreChaos"); err != nil {
+			return err
+		}
+	case "Continuous":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Host":           inputs.Host,
+			"Service":        inputs.Service,
+			"Method":         inputs.Method,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		go triggerContinuousGRPCProbe(probe, clients, resultDetails, chaosDetails)
+
+	}
+	return nil
+}
+
+// postChaosGRPCProbe trigger the grpc probe for postchaos phase
+func postChaosGRPCProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, delay int, timeout int) error {
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).GRPCProbeInputs
+
+	switch probe.Mode {
+	case "EOT", "Edge":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Host":           inputs.Host,
+			"Service":        inputs.Service,
+			"Method":         inputs.Method,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PostChaos",
+		})
+
+		// waiting for initial delay
+		if probeTimeout.InitialDelay != 0 {
+			log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+			time.Sleep(probeTimeout.InitialDelay)
+		}
+
+		// trigger the grpc probe
+		if err = triggerGRPCProbe(probe, resultDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeGRPCProbe {
+			return err
+		}
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	case "Continuous", "OnChaos":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		if err = checkForErrorInContinuousProbe(resultDetails, probe.Name, delay, timeout); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeGRPCProbe && cerrors.GetErrorType(err) != cerrors.FailureTypeProbeTimeout {
+			return err
+		}
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// triggerOnChaosGRPCProbe trigger the onchaos grpc probes
+func triggerOnChaosGRPCProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+
+	var isExperimentFailed bool
+	duration := chaosDetails.ChaosDuration
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+		duration = math.Maximum(0, duration-int(probeTimeout.InitialDelay.Seconds()))
+	}
+
+	endTime := time.After(time.Duration(duration) * time.Second)
+
+	// it trigger the grpc probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		select {
+		case <-endTime:
+			log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+			endTime = nil
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].HasProbeCompleted = true
+				}
+			}
+			break loop
+		default:
+			err = triggerGRPCProbe(probe, chaosresult)
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+						chaosresult.ProbeDetails[index].Status.Description = getDescription(err)
+						log.Errorf("The %v grpc probe has been Failed, err: %v", probe.Name, err)
+						isExperimentFailed = true
+						break loop
+					}
+				}
+			}
+
+			select {
+			case <-chaosDetails.ProbeContext.Ctx.Done():
+				log.Infof("Stopping %s continuous Probe", probe.Name)
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+					}
+				}
+				break loop
+			default:
+				// waiting for the probe polling interval
+				time.Sleep(probeTimeout.ProbePollingInterval)
+			}
+		}
+	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
+	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+	// and failed the experiment in the end
+	if isExperimentFailed && probe.RunProperties.StopOnFailure {
+		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("unable to patch chaosengine to stop, err: %v", err)
+		}
+	}
+}
+
+// onChaosGRPCProbe trigger the grpc probe for DuringChaos phase
+func onChaosGRPCProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).GRPCProbeInputs
+
+	switch probe.Mode {
+	case "OnChaos":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Host":           inputs.Host,
+			"Service":        inputs.Service,
+			"Method":         inputs.Method,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+		go triggerOnChaosGRPCProbe(probe, clients, resultDetails, chaosDetails)
+	}
+
+}
@@ -0,0 +1,86 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// validateHaltProbe validate the halt probe
+// the halt is supported only for the probes which are evaluated during chaos
+func validateHaltProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) error {
+	if !getProbeInputs(probe.Name, resultDetails.ProbeDetails).Halt {
+		return nil
+	}
+	switch strings.ToLower(probe.Mode) {
+	case "continuous", "onchaos":
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("halt is not supported in %s mode, use continuous or onchaos mode", probe.Mode)}
+	}
+	// the log and event probes are evaluated only at the end of chaos
+	switch strings.ToLower(probe.Type) {
+	case "logprobe", "eventprobe":
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("halt is not supported in the %s", probe.Type)}
+	}
+	return nil
+}
+
+// haltChaos reverts the chaos immediately, if the failed probe is a halt probe
+// it records the breaching probe, stops all the probes and aborts the experiment to revert all the targets
+// the abort watcher marks the verdict as stopped, the failures of the halted experiment are not recorded
+func haltChaos(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	if !getProbeInputs(probe.Name, chaosresult.ProbeDetails).Halt {
+		return
+	}
+	if !chaosDetails.ProbeContext.Halt.Record(probe.Name) {
+		return
+	}
+	log.Errorf("[Halt]: The %v halt probe has been breached, reverting the chaos", probe.Name)
+
+	// failing the probe, as the experiment is stopped before the postchaos evaluation
+	markedVerdictInEnd(checkForErrorInContinuousProbe(chaosresult, probe.Name, chaosDetails.Delay, chaosDetails.Timeout), chaosresult, probe, "PostChaos")
+	// adding signal to communicate that experiment is stopped because of the halt probe
+	if probeDetails := getProbeByName(probe.Name, chaosresult.ProbeDetails); probeDetails != nil {
+		probeDetails.Stopped = true
+	}
+
+	// stop all the other probes
+	chaosDetails.ProbeContext.CancelFunc()
+
+	// deleting the helper pods, the helpers revert the chaos once they receive the termination signal
+	if err := deleteHelperPods(clients, chaosDetails); err != nil {
+		log.Errorf("[Halt]: Unable to delete the helper pods, err: %v", err)
+	}
+
+	// signal the chaoslib and abort watcher to revert the chaos and update the chaosresult
+	types.Abort()
+}
+
+// deleteHelperPods deletes the helper pods of the experiment
+// the helper pods are selected by the labels set via common.GetHelperLabels, i.e, the experiment pod labels along with the helper app label
+func deleteHelperPods(clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	if !strings.HasPrefix(chaosDetails.Labels["app"], chaosDetails.ExperimentName+"-helper-") {
+		// no helper pod is created by the experiment
+		return nil
+	}
+	podList, err := clients.KubeClient.CoreV1().Pods(chaosDetails.ChaosNamespace).List(context.Background(), v1.ListOptions{LabelSelector: labels.SelectorFromSet(chaosDetails.Labels).String()})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{namespace: %s}", chaosDetails.ChaosNamespace), Reason: fmt.Sprintf("failed to list helper pod(s): %s", err.Error())}
+	}
+	for _, pod := range podList.Items {
+		log.Infof("[Halt]: Deleting %v helper pod", pod.Name)
+		if err := clients.KubeClient.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("failed to delete helper pod: %s", err.Error())}
+		}
+	}
+	return nil
+}
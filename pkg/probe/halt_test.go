@@ -0,0 +1,58 @@
+package probe
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestHaltChaosWithoutHalt(t *testing.T) {
+	// the chaos is halted only by the halt probes, the halt of the experiment is tested along with the abort watcher
+	probeDetails := &types.ProbeDetails{Name: "http-probe", Type: "httpProbe", Mode: "Continuous"}
+	chaosDetails := &types.ChaosDetails{ExperimentName: "pod-delete", ProbeContext: types.ProbeContext{Halt: &types.ProbeHalt{}}}
+	haltChaos(v1alpha1.ProbeAttributes{Name: "http-probe", Type: "httpProbe", Mode: "Continuous"}, clients.ClientSets{}, &types.ResultDetails{ProbeDetails: []*types.ProbeDetails{probeDetails}}, chaosDetails)
+
+	assert.Empty(t, chaosDetails.ProbeContext.Halt.ProbeName())
+	assert.False(t, probeDetails.Stopped)
+	assert.False(t, types.IsAborted())
+}
+
+func TestDeleteHelperPods(t *testing.T) {
+	var selectors, deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/namespaces/litmus/pods":
+			selectors = append(selectors, r.URL.Query().Get("labelSelector"))
+			_ = json.NewEncoder(w).Encode(corev1.PodList{Items: []corev1.Pod{{ObjectMeta: v1.ObjectMeta{Name: "pod-delete-helper-hxwkt", Namespace: "litmus"}}}})
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(v1.Status{Status: v1.StatusSuccess})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	clientSets := clients.ClientSets{KubeClient: kubernetes.NewForConfigOrDie(&rest.Config{Host: server.URL})}
+
+	// no helper pod is created by the experiment
+	chaosDetails := &types.ChaosDetails{ExperimentName: "pod-delete", ChaosNamespace: "litmus", Labels: map[string]string{"app": "pod-delete", "chaosUID": "1234"}}
+	require.NoError(t, deleteHelperPods(clientSets, chaosDetails))
+	assert.Empty(t, selectors)
+
+	chaosDetails.Labels["app"] = "pod-delete-helper-abcde"
+	require.NoError(t, deleteHelperPods(clientSets, chaosDetails))
+	assert.Equal(t, []string{"app=pod-delete-helper-abcde,chaosUID=1234"}, selectors)
+	assert.Equal(t, []string{"/api/v1/namespaces/litmus/pods/pod-delete-helper-hxwkt"}, deleted)
+}
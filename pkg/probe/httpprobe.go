@@ -2,8 +2,10 @@ package probe
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
-	"github.com/figwood/litmus-go/pkg/utils"
+	"io"
 	"os/exec"
 	"reflect"
 	"strconv"
@@ -13,21 +15,30 @@ import (
 	"crypto/tls"
 	"net/http"
 
-	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	"github.com/figwood/litmus-go/pkg/cerrors"
 	"github.com/figwood/litmus-go/pkg/clients"
 	"github.com/figwood/litmus-go/pkg/log"
 	"github.com/figwood/litmus-go/pkg/math"
 	cmp "github.com/figwood/litmus-go/pkg/probe/comparator"
 	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/figwood/litmus-go/pkg/utils"
 	"github.com/figwood/litmus-go/pkg/utils/retry"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	"github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // prepareHTTPProbe contains the steps to prepare the http probe
 // http probe can be used to add the probe which will send a request to given url and match the status code
 func prepareHTTPProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
 
+	// deriving the header values from the secrets
+	if inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).HTTPProbeInputs; inputs != nil {
+		if err := setHeaderValues(inputs.Headers, probe.Name, clients, chaosDetails.ChaosNamespace, cerrors.ErrorTypeHttpProbe); err != nil {
+			return err
+		}
+	}
+
 	switch strings.ToLower(phase) {
 	case "prechaos":
 		if err := preChaosHTTPProbe(probe, resultDetails, clients, chaosDetails); err != nil {
@@ -45,9 +56,23 @@ func prepareHTTPProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets
 	return nil
 }
 
+// httpRequest contains the details of the http request and the expected response code
+type httpRequest struct {
+	method       string
+	contentType  string
+	body         string
+	criteria     string
+	responseCode string
+}
+
 // triggerHTTPProbe run the http probe command
-func triggerHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) error {
+func triggerHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, stats *httpLatencyStats) (err error) {
+	defer observeProbe(probe).end(&err)
 	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).HTTPProbeInputs
+	if inputs == nil {
+		inputs = &types.HTTPProbeInputs{}
+	}
 
 	// It parses the templated url and return normal string
 	// if command doesn't have template, it will return the same command
@@ -56,20 +81,27 @@ func triggerHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 		return err
 	}
 
-	// it fetches the http method type
-	method := getHTTPMethodType(probe.HTTPProbeInputs.Method)
-
 	// initialize simple http client with default attributes
 	client := &http.Client{Timeout: probeTimeout.ProbeTimeout}
-	// impose properties to http client with cert check disabled
-	if probe.HTTPProbeInputs.InsecureSkipVerify {
+	switch {
+	case inputs.TLS != nil:
+		// impose the ca bundle, client certificates and server name to http client
+		tlsConfig, err := getTLSConfig(inputs.TLS, probe.Name, cerrors.ErrorTypeHttpProbe)
+		if err != nil {
+			return err
+		}
+		tlsConfig.InsecureSkipVerify = tlsConfig.InsecureSkipVerify || probe.HTTPProbeInputs.InsecureSkipVerify
+		client = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}, Timeout: probeTimeout.ProbeTimeout}
+	case probe.HTTPProbeInputs.InsecureSkipVerify:
+		// impose properties to http client with cert check disabled
 		transCfg := &http.Transport{
 			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 		}
 		client = &http.Client{Transport: transCfg, Timeout: probeTimeout.ProbeTimeout}
 	}
 
-	switch method {
+	// it fetches the http method type
+	switch method := getHTTPMethodType(probe.HTTPProbeInputs.Method, inputs.Method); method {
 	case "Get":
 		log.InfoWithValues("[Probe]: HTTP get method informations", logrus.Fields{
 			"Name":            probe.Name,
@@ -78,7 +110,7 @@ func triggerHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			"ResponseCode":    probe.HTTPProbeInputs.Method.Get.ResponseCode,
 			"ResponseTimeout": probe.RunProperties.ProbeTimeout,
 		})
-		return httpGet(probe, client, resultDetails)
+		return httpGet(probe, client, inputs, resultDetails, stats)
 	case "Post":
 		log.InfoWithValues("[Probe]: HTTP Post method informations", logrus.Fields{
 			"Name":            probe.Name,
@@ -88,22 +120,86 @@ func triggerHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			"ContentType":     probe.HTTPProbeInputs.Method.Post.ContentType,
 			"ResponseTimeout": probe.RunProperties.ProbeTimeout,
 		})
-		return httpPost(probe, client, resultDetails)
+		return httpPost(probe, client, inputs, resultDetails, stats)
+	default:
+		log.InfoWithValues(fmt.Sprintf("[Probe]: HTTP %v method informations", method), logrus.Fields{
+			"Name":            probe.Name,
+			"URL":             probe.HTTPProbeInputs.URL,
+			"Body":            inputs.Method.Body,
+			"BodyPath":        inputs.Method.BodyPath,
+			"ContentType":     inputs.Method.ContentType,
+			"Criteria":        inputs.Method.Criteria,
+			"ResponseCode":    inputs.Method.ResponseCode,
+			"ResponseTimeout": probe.RunProperties.ProbeTimeout,
+		})
+		if !isHTTPMethodSupported(method) {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("method '%s' not supported in the http probe", inputs.Method.Name)}
+		}
+		var body string
+		if inputs.Method.Body != "" || inputs.Method.BodyPath != "" {
+			if body, err = getHTTPBody(&v1alpha1.PostMethod{Body: inputs.Method.Body, BodyPath: inputs.Method.BodyPath}, probe.Name); err != nil {
+				return err
+			}
+		}
+		return httpSendRequest(probe, client, inputs, resultDetails, stats, httpRequest{
+			method:       method,
+			contentType:  inputs.Method.ContentType,
+			body:         body,
+			criteria:     inputs.Method.Criteria,
+			responseCode: inputs.Method.ResponseCode,
+		})
 	}
-	return nil
 }
 
 // it fetches the http method type
-// it supports Get and Post methods
-func getHTTPMethodType(httpMethod v1alpha1.HTTPMethod) string {
+// it supports Get and Post methods, other methods can be provided via additional inputs
+func getHTTPMethodType(httpMethod v1alpha1.HTTPMethod, method *types.HTTPMethod) string {
+	if method != nil {
+		return strings.ToUpper(method.Name)
+	}
 	if !reflect.DeepEqual(httpMethod.Get, v1alpha1.GetMethod{}) {
 		return "Get"
 	}
 	return "Post"
 }
 
+// isHTTPMethodSupported check whether the http method is supported by the http probe
+func isHTTPMethodSupported(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead:
+		return true
+	}
+	return false
+}
+
 // httpGet send the http Get request to the given URL and verify the response code to follow the specified criteria
-func httpGet(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails *types.ResultDetails) error {
+func httpGet(probe v1alpha1.ProbeAttributes, client *http.Client, inputs *types.HTTPProbeInputs, resultDetails *types.ResultDetails, stats *httpLatencyStats) error {
+	return httpSendRequest(probe, client, inputs, resultDetails, stats, httpRequest{
+		method:       http.MethodGet,
+		criteria:     probe.HTTPProbeInputs.Method.Get.Criteria,
+		responseCode: probe.HTTPProbeInputs.Method.Get.ResponseCode,
+	})
+}
+
+// httpPost send the http post request to the given URL
+func httpPost(probe v1alpha1.ProbeAttributes, client *http.Client, inputs *types.HTTPProbeInputs, resultDetails *types.ResultDetails, stats *httpLatencyStats) error {
+	body, err := getHTTPBody(probe.HTTPProbeInputs.Method.Post, probe.Name)
+	if err != nil {
+		return err
+	}
+	return httpSendRequest(probe, client, inputs, resultDetails, stats, httpRequest{
+		method:       http.MethodPost,
+		contentType:  probe.HTTPProbeInputs.Method.Post.ContentType,
+		body:         body,
+		criteria:     probe.HTTPProbeInputs.Method.Post.Criteria,
+		responseCode: probe.HTTPProbeInputs.Method.Post.ResponseCode,
+	})
+}
+
+// httpSendRequest send the http request to the given URL and verify the response code to follow the specified criteria
+// it also verify the response body and headers, if provided
+// the latency and outcome of each request is recorded inside the stats, if provided
+func httpSendRequest(probe v1alpha1.ProbeAttributes, client *http.Client, inputs *types.HTTPProbeInputs, resultDetails *types.ResultDetails, stats *httpLatencyStats, request httpRequest) error {
 	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
 	var description string
 
@@ -112,15 +208,34 @@ func httpGet(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails
 	// for a timeout, it will run the command, if it fails wait for the interval and again execute the command until timeout expires
 	if err := retry.Times(uint(getAttempts(probe.RunProperties.Attempt, probe.RunProperties.Retry))).
 		Wait(probeTimeout.Interval).
+		Context(types.AbortContext()).
 		Try(func(attempt uint) error {
+			req, err := http.NewRequest(request.method, probe.HTTPProbeInputs.URL, strings.NewReader(request.body))
+			if err != nil {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: err.Error()}
+			}
+			if request.contentType != "" {
+				req.Header.Set("Content-Type", request.contentType)
+			}
+			for _, header := range inputs.Headers {
+				req.Header.Set(header.Name, header.Value)
+			}
+
 			// getting the response from the given url
-			resp, err := client.Get(probe.HTTPProbeInputs.URL)
+			startTime := time.Now()
+			resp, err := client.Do(req)
+			latency := time.Since(startTime)
 			if err != nil {
+				stats.record(latency, false)
+				if reason, ok := getTLSErrorReason(err); ok {
+					return cerrors.Error{ErrorCode: cerrors.ErrorTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("tls handshake with %s failed, %s", req.URL.Host, reason)}
+				}
 				if utils.HttpTimeout(err) {
 					return cerrors.Error{ErrorCode: cerrors.FailureTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: err.Error()}
 				}
 				return cerrors.Error{ErrorCode: cerrors.ErrorTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: err.Error()}
 			}
+			defer resp.Body.Close()
 
 			code := strconv.Itoa(resp.StatusCode)
 			rc := getAndIncrementRunCount(resultDetails, probe.Name)
@@ -128,15 +243,43 @@ func httpGet(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails
 			// comparing the response code with the expected criteria
 			if err = cmp.RunCount(rc).
 				FirstValue(code).
-				SecondValue(probe.HTTPProbeInputs.Method.Get.ResponseCode).
-				Criteria(probe.HTTPProbeInputs.Method.Get.Criteria).
+				SecondValue(request.responseCode).
+				Criteria(request.criteria).
 				ProbeName(probe.Name).
 				ProbeVerbosity(probe.RunProperties.Verbosity).
 				CompareInt(cerrors.FailureTypeHttpProbe); err != nil {
-				log.Errorf("The %v http probe get method has Failed, err: %v", probe.Name, err)
+				log.Errorf("The %v http probe %v method has Failed, err: %v", probe.Name, strings.ToLower(request.method), err)
+				stats.record(latency, false)
 				return err
 			}
-			description = fmt.Sprintf("The URL %s did respond with correct status code. Actual code: '%s'. Expected code: '%s'", probe.HTTPProbeInputs.URL, code, probe.HTTPProbeInputs.Method.Get.ResponseCode)
+
+			// the response body is read only if it is required by the response checks or the named outputs
+			var body []byte
+			outputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).Outputs
+			if len(inputs.ResponseBody) != 0 || len(outputs) != 0 {
+				if body, err = io.ReadAll(resp.Body); err != nil {
+					stats.record(latency, false)
+					return cerrors.Error{ErrorCode: cerrors.FailureTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to read the response body, err: %v", err)}
+				}
+			}
+
+			if err := validateHTTPResponse(probe, inputs, resp.Header, body, rc); err != nil {
+				log.Errorf("The %v http probe %v method has Failed, err: %v", probe.Name, strings.ToLower(request.method), err)
+				stats.record(latency, false)
+				return err
+			}
+			stats.record(latency, true)
+
+			// register the response body along with the named outputs, which can be referenced by the later probes
+			if len(outputs) != 0 {
+				if err := registerProbeOutput(probe, string(body), body, resultDetails, cerrors.FailureTypeHttpProbe); err != nil {
+					return err
+				}
+			}
+			description = fmt.Sprintf("The URL %s did respond with correct status code. Actual code: '%s'. Expected code: '%s'", probe.HTTPProbeInputs.URL, code, request.responseCode)
+			if len(inputs.ResponseBody) != 0 || len(inputs.ResponseHeaders) != 0 {
+				description += ". The response body and headers are matched with the expected criteria"
+			}
 			return nil
 		}); err != nil {
 		return err
@@ -145,49 +288,76 @@ func httpGet(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails
 	return nil
 }
 
-// httpPost send the http post request to the given URL
-func httpPost(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails *types.ResultDetails) error {
-	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
-	body, err := getHTTPBody(probe.HTTPProbeInputs.Method.Post, probe.Name)
-	if err != nil {
-		return err
+// validateHTTPResponse verify the response headers and body to follow the specified criteria
+func validateHTTPResponse(probe v1alpha1.ProbeAttributes, inputs *types.HTTPProbeInputs, headers http.Header, body []byte, rc int) error {
+	for _, header := range inputs.ResponseHeaders {
+		if err := compareHTTPValue(probe, header.Comparator, headers.Get(header.Name), rc); err != nil {
+			return err
+		}
 	}
 
-	var description string
-
-	// it will retry for some retry count, in each iteration of try it contains following things
-	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
-	// for a timeout, it will run the command, if it fails wait for the interval and again execute the command until timeout expires
-	if err := retry.Times(uint(getAttempts(probe.RunProperties.Attempt, probe.RunProperties.Retry))).
-		Wait(probeTimeout.Interval).
-		Try(func(attempt uint) error {
-			resp, err := client.Post(probe.HTTPProbeInputs.URL, probe.HTTPProbeInputs.Method.Post.ContentType, strings.NewReader(body))
-			if err != nil {
-				if utils.HttpTimeout(err) {
-					return cerrors.Error{ErrorCode: cerrors.FailureTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: err.Error()}
+	var err error
+	var data interface{}
+	for _, check := range inputs.ResponseBody {
+		value := string(body)
+		if check.JSONPath != "" {
+			if data == nil {
+				if err := json.Unmarshal(body, &data); err != nil {
+					return cerrors.Error{ErrorCode: cerrors.FailureTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to parse the response body as json, err: %v", err)}
 				}
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: err.Error()}
 			}
-			code := strconv.Itoa(resp.StatusCode)
-			rc := getAndIncrementRunCount(resultDetails, probe.Name)
-
-			// comparing the response code with the expected criteria
-			if err = cmp.RunCount(rc).
-				FirstValue(code).
-				SecondValue(probe.HTTPProbeInputs.Method.Post.ResponseCode).
-				Criteria(probe.HTTPProbeInputs.Method.Post.Criteria).
-				ProbeName(probe.Name).
-				ProbeVerbosity(probe.RunProperties.Verbosity).
-				CompareInt(cerrors.FailureTypeHttpProbe); err != nil {
-				log.Errorf("The %v http probe post method has Failed, err: %v", probe.Name, err)
+			if value, err = getJSONPathValue(data, check.JSONPath, probe.Name, cerrors.FailureTypeHttpProbe); err != nil {
 				return err
 			}
-			description = fmt.Sprintf("The URL %s did respond with correct status code. Actual code: '%s'. Expected code: '%s'", probe.HTTPProbeInputs.URL, code, probe.HTTPProbeInputs.Method.Get.ResponseCode)
-			return nil
-		}); err != nil {
-		return err
+		}
+		if err := compareHTTPValue(probe, check.Comparator, value, rc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compareHTTPValue compares the actual value with the expected value based on the comparator type
+func compareHTTPValue(probe v1alpha1.ProbeAttributes, comparator v1alpha1.ComparatorInfo, value string, rc int) error {
+	compare := cmp.RunCount(rc).
+		FirstValue(value).
+		SecondValue(comparator.Value).
+		Criteria(comparator.Criteria).
+		ProbeName(probe.Name).
+		ProbeVerbosity(probe.RunProperties.Verbosity)
+
+	comparatorType := comparator.Type
+	if comparatorType == "" {
+		comparatorType = "string"
+	}
+	if !cmp.IsSupportedType(comparatorType) {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("comparator type '%s' not supported in the http probe", comparator.Type)}
+	}
+	return compare.Compare(comparatorType, cerrors.FailureTypeHttpProbe)
+}
+
+// setHeaderValues derive the values of the request headers from the secrets
+// the secrets should be present inside the chaos namespace
+func setHeaderValues(headers []types.HTTPHeader, probeName string, clients clients.ClientSets, chaosNamespace string, errorCode cerrors.ErrorType) error {
+	for index, header := range headers {
+		if header.ValueFrom == nil || header.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		ref := header.ValueFrom.SecretKeyRef
+		secret, err := clients.KubeClient.CoreV1().Secrets(chaosNamespace).Get(context.Background(), ref.Name, v1.GetOptions{})
+		if err != nil {
+			return cerrors.Error{ErrorCode: errorCode, Target: fmt.Sprintf("{name: %v, secret: %v}", probeName, ref.Name), Reason: fmt.Sprintf("unable to get the secret for header '%s', err: %v", header.Name, err)}
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			if ref.Optional != nil && *ref.Optional {
+				continue
+			}
+			return cerrors.Error{ErrorCode: errorCode, Target: fmt.Sprintf("{name: %v, secret: %v}", probeName, ref.Name), Reason: fmt.Sprintf("key '%s' not found inside the secret for header '%s'", ref.Key, header.Name)}
+		}
+		headers[index].Value = strings.TrimSpace(string(value))
+		log.RegisterSecret(headers[index].Value)
 	}
-	setProbeDescription(resultDetails, probe, description)
 	return nil
 }
 
@@ -229,6 +399,14 @@ func triggerContinuousHTTPProbe(probe v1alpha1.ProbeAttributes, clients clients.
 		time.Sleep(probeTimeout.InitialDelay)
 	}
 
+	// it records the latency and outcome of all the requests, if slo is provided
+	// the failed requests are evaluated against the slo at the end instead of failing the probe
+	var stats *httpLatencyStats
+	inputs := getProbeInputs(probe.Name, chaosresult.ProbeDetails).HTTPProbeInputs
+	if inputs != nil && inputs.SLO != nil {
+		stats = &httpLatencyStats{}
+	}
+
 	// it triggers the http probe for the entire duration of chaos and it fails, if any error encounter
 	// it marked the error for the probes, if any
 
@@ -239,14 +417,25 @@ loop:
 			log.Infof("Stopping %s continuous Probe", probe.Name)
 			for index := range chaosresult.ProbeDetails {
 				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					if stats != nil {
+						description, err := evaluateHTTPSLO(probe, inputs.SLO, stats)
+						if err != nil {
+							chaosresult.ProbeDetails[index].IsProbeFailedWithError = addProbePhase(err, string(chaosDetails.Phase))
+							description = getDescription(err)
+							log.Errorf("The %v http probe has been Failed, err: %v", probe.Name, err)
+						}
+						chaosresult.ProbeDetails[index].Status.Description = description
+					}
 					chaosresult.ProbeDetails[index].HasProbeCompleted = true
 				}
 			}
 			break loop
 		default:
-			err = triggerHTTPProbe(probe, chaosresult)
+			requests := stats.total()
+			err = triggerHTTPProbe(probe, chaosresult, stats)
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
-			if err != nil {
+			// the errors of the recorded requests are evaluated against the slo at the end
+			if err != nil && (stats == nil || stats.total() == requests) {
 				err = addProbePhase(err, string(chaosDetails.Phase))
 				for index := range chaosresult.ProbeDetails {
 					if chaosresult.ProbeDetails[index].Name == probe.Name {
@@ -263,6 +452,10 @@ loop:
 			time.Sleep(probeTimeout.ProbePollingInterval)
 		}
 	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
 	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
 	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
 	// and failed the experiment in the end
@@ -295,7 +488,7 @@ func preChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 			time.Sleep(probeTimeout.InitialDelay)
 		}
 		// trigger the http probe
-		if err = triggerHTTPProbe(probe, resultDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeHttpProbe {
+		if err = triggerHTTPProbe(probe, resultDetails, nil); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeHttpProbe {
 			return err
 		}
 
@@ -344,7 +537,7 @@ func postChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 		}
 
 		// trigger the http probe
-		if err = triggerHTTPProbe(probe, resultDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeHttpProbe {
+		if err = triggerHTTPProbe(probe, resultDetails, nil); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeHttpProbe {
 			return err
 		}
 
@@ -396,7 +589,7 @@ loop:
 			}
 			break loop
 		default:
-			err = triggerHTTPProbe(probe, chaosresult)
+			err = triggerHTTPProbe(probe, chaosresult, nil)
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 			if err != nil {
 				err = addProbePhase(err, string(chaosDetails.Phase))
@@ -426,6 +619,10 @@ loop:
 			}
 		}
 	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
 	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
 	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
 	// and failed the experiment in the end
@@ -0,0 +1,93 @@
+package probe
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/math"
+	cmp "github.com/figwood/litmus-go/pkg/probe/comparator"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+)
+
+// httpLatencyStats contains the latency(in ms) and outcome of all the requests of the continuous http probe
+type httpLatencyStats struct {
+	latencies []float64
+	failures  int
+}
+
+// record adds the latency and outcome of the request
+// it is a no-op for the nil stats, so the probes without slo can pass the nil stats
+func (stats *httpLatencyStats) record(latency time.Duration, success bool) {
+	if stats == nil {
+		return
+	}
+	stats.latencies = append(stats.latencies, float64(latency.Microseconds())/1000)
+	if !success {
+		stats.failures++
+	}
+}
+
+// total returns the total number of the recorded requests
+func (stats *httpLatencyStats) total() int {
+	if stats == nil {
+		return 0
+	}
+	return len(stats.latencies)
+}
+
+// errorRate returns the percentage of the failed requests
+func (stats *httpLatencyStats) errorRate() float64 {
+	if stats.total() == 0 {
+		return 0
+	}
+	return float64(stats.failures) * 100 / float64(stats.total())
+}
+
+// String returns the summary of the recorded requests
+func (stats *httpLatencyStats) String() string {
+	latencies := append([]float64{}, stats.latencies...)
+	sort.Float64s(latencies)
+	return fmt.Sprintf("Requests: %d, Errors: %d (%.2f%%), Latency(ms): p50=%.3f, p95=%.3f, p99=%.3f, max=%.3f",
+		stats.total(), stats.failures, stats.errorRate(), math.Percentile(latencies, 50), math.Percentile(latencies, 95), math.Percentile(latencies, 99), math.Percentile(latencies, 100))
+}
+
+// evaluateHTTPSLO verify the latency percentiles and error rate of the recorded requests to follow the slo
+// it returns the summary of the recorded requests as the description
+func evaluateHTTPSLO(probe v1alpha1.ProbeAttributes, slo *types.HTTPSLO, stats *httpLatencyStats) (string, error) {
+	if stats.total() == 0 {
+		return "", cerrors.Error{ErrorCode: cerrors.FailureTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "no request has been sent during the chaos"}
+	}
+
+	latencies := append([]float64{}, stats.latencies...)
+	sort.Float64s(latencies)
+
+	checks := []struct {
+		name, threshold string
+		value           float64
+	}{
+		{"error rate(%)", slo.ErrorBudget, stats.errorRate()},
+		{"p50 latency(ms)", slo.P50, math.Percentile(latencies, 50)},
+		{"p95 latency(ms)", slo.P95, math.Percentile(latencies, 95)},
+		{"p99 latency(ms)", slo.P99, math.Percentile(latencies, 99)},
+	}
+	for _, check := range checks {
+		if strings.TrimSpace(check.threshold) == "" {
+			continue
+		}
+		if err := cmp.RunCount(1).
+			FirstValue(strconv.FormatFloat(check.value, 'f', -1, 64)).
+			SecondValue(check.threshold).
+			Criteria("<=").
+			ProbeName(probe.Name).
+			ProbeVerbosity(probe.RunProperties.Verbosity).
+			CompareFloat(cerrors.FailureTypeHttpProbe); err != nil {
+			return "", cerrors.Error{ErrorCode: cerrors.FailureTypeHttpProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("The %s has breached the slo. %s. %s", check.name, getDescription(err), stats.String())}
+		}
+	}
+	return fmt.Sprintf("The URL %s did meet the slo. %s", probe.HTTPProbeInputs.URL, stats.String()), nil
+}
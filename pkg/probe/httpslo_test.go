@@ -0,0 +1,85 @@
+package probe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getLatencyStats returns the stats of the given requests, the failed requests are recorded first
+func getLatencyStats(requests, failures int, latency time.Duration) *httpLatencyStats {
+	stats := &httpLatencyStats{}
+	for i := 0; i < requests; i++ {
+		stats.record(latency, i >= failures)
+	}
+	return stats
+}
+
+func TestHTTPLatencyStatsErrorRate(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats *httpLatencyStats
+		want  float64
+	}{
+		{name: "nil stats", stats: nil, want: 0},
+		{name: "empty sample", stats: &httpLatencyStats{}, want: 0},
+		{name: "single success", stats: getLatencyStats(1, 0, time.Millisecond), want: 0},
+		{name: "single failure", stats: getLatencyStats(1, 1, time.Millisecond), want: 100},
+		{name: "one in hundred", stats: getLatencyStats(100, 1, time.Millisecond), want: 1},
+		{name: "one in three", stats: getLatencyStats(3, 1, time.Millisecond), want: 100.0 / 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.stats.errorRate(), 1e-9)
+		})
+	}
+}
+
+func TestEvaluateHTTPSLO(t *testing.T) {
+	variedLatencies := &httpLatencyStats{}
+	for i := 1; i <= 100; i++ {
+		variedLatencies.record(time.Duration(i)*time.Millisecond, true)
+	}
+
+	tests := []struct {
+		name    string
+		slo     types.HTTPSLO
+		stats   *httpLatencyStats
+		wantErr bool
+	}{
+		{name: "empty sample", slo: types.HTTPSLO{ErrorBudget: "1"}, stats: &httpLatencyStats{}, wantErr: true},
+		{name: "no thresholds", slo: types.HTTPSLO{}, stats: getLatencyStats(10, 10, time.Millisecond)},
+		{name: "single sample within budget", slo: types.HTTPSLO{ErrorBudget: "0"}, stats: getLatencyStats(1, 0, time.Millisecond)},
+		{name: "single sample breached budget", slo: types.HTTPSLO{ErrorBudget: "99"}, stats: getLatencyStats(1, 1, time.Millisecond), wantErr: true},
+		{name: "error rate equal to budget", slo: types.HTTPSLO{ErrorBudget: "1"}, stats: getLatencyStats(100, 1, time.Millisecond)},
+		{name: "error rate above budget", slo: types.HTTPSLO{ErrorBudget: "1"}, stats: getLatencyStats(100, 2, time.Millisecond), wantErr: true},
+		// 1/999 = 0.1001%, it shouldn't be rounded down to the budget
+		{name: "error rate above budget after rounding", slo: types.HTTPSLO{ErrorBudget: "0.1"}, stats: getLatencyStats(999, 1, time.Millisecond), wantErr: true},
+		{name: "error rate below budget after rounding", slo: types.HTTPSLO{ErrorBudget: "0.1"}, stats: getLatencyStats(1001, 1, time.Millisecond)},
+		{name: "p50 within slo", slo: types.HTTPSLO{P50: "50"}, stats: variedLatencies},
+		{name: "p50 breached", slo: types.HTTPSLO{P50: "49.9"}, stats: variedLatencies, wantErr: true},
+		{name: "p95 within slo", slo: types.HTTPSLO{P95: "95"}, stats: variedLatencies},
+		{name: "p95 breached", slo: types.HTTPSLO{P95: "94"}, stats: variedLatencies, wantErr: true},
+		{name: "p99 within slo", slo: types.HTTPSLO{P99: "99"}, stats: variedLatencies},
+		{name: "p99 breached", slo: types.HTTPSLO{P99: "98.5"}, stats: variedLatencies, wantErr: true},
+		{name: "sub millisecond latency", slo: types.HTTPSLO{P99: "0.25"}, stats: getLatencyStats(10, 0, 250*time.Microsecond)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := v1alpha1.ProbeAttributes{Name: "http-probe", HTTPProbeInputs: &v1alpha1.HTTPProbeInputs{URL: "http://nginx.default.svc"}}
+			description, err := evaluateHTTPSLO(probe, &tt.slo, tt.stats)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, cerrors.FailureTypeHttpProbe, cerrors.GetErrorType(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, description, "did meet the slo")
+		})
+	}
+}
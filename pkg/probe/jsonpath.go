@@ -0,0 +1,30 @@
+package probe
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// getJSONPathValue returns the value of the jsonpath expression from the given object
+// the expression can be provided with or without the curly braces, e.g. {.data.id} or .data.id
+func getJSONPathValue(data interface{}, path, probeName string, errorCode cerrors.ErrorType) (string, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+
+	parser := jsonpath.New(probeName)
+	if err := parser.Parse(path); err != nil {
+		return "", cerrors.Error{ErrorCode: errorCode, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("The probe jsonpath '%s' is not a valid expression, err: %v", path, err)}
+	}
+
+	var out bytes.Buffer
+	if err := parser.Execute(&out, data); err != nil {
+		return "", cerrors.Error{ErrorCode: errorCode, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to find the jsonpath '%s', err: %v", path, err)}
+	}
+	return out.String(), nil
+}
@@ -2,7 +2,9 @@ package probe
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/figwood/litmus-go/pkg/clients"
 	"github.com/figwood/litmus-go/pkg/log"
 	"github.com/figwood/litmus-go/pkg/math"
+	cmp "github.com/figwood/litmus-go/pkg/probe/comparator"
 	"github.com/figwood/litmus-go/pkg/types"
 	"github.com/figwood/litmus-go/pkg/utils/retry"
 	"github.com/sirupsen/logrus"
@@ -20,6 +23,9 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // prepareK8sProbe contains the steps to prepare the k8s probe
@@ -43,36 +49,17 @@ func prepareK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 }
 
 // triggerK8sProbe run the k8s probe command
-func triggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+func triggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails) (err error) {
+	defer observeProbe(probe).end(&err)
 	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
 
 	inputs := probe.K8sProbeInputs
 
-	// It parses the templated command and return normal string
-	// if command doesn't have template, it will return the same command
-	inputs.FieldSelector, err = parseCommand(inputs.FieldSelector, resultDetails)
+	parsedResourceNames, err := parseK8sProbeInputs(inputs, resultDetails)
 	if err != nil {
 		return err
 	}
 
-	inputs.LabelSelector, err = parseCommand(inputs.LabelSelector, resultDetails)
-	if err != nil {
-		return err
-	}
-
-	inputs.ResourceNames, err = parseCommand(inputs.ResourceNames, resultDetails)
-	if err != nil {
-		return err
-	}
-
-	parsedResourceNames := []string{}
-	if inputs.ResourceNames != "" {
-		parsedResourceNames = strings.Split(inputs.ResourceNames, ",")
-		for i := range parsedResourceNames {
-			parsedResourceNames[i] = strings.TrimSpace(parsedResourceNames[i])
-		}
-	}
-
 	var description string
 
 	// it will retry for some retry count, in each iteration of try it contains following things
@@ -81,6 +68,7 @@ func triggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 	if err := retry.Times(uint(getAttempts(probe.RunProperties.Attempt, probe.RunProperties.Retry))).
 		Timeout(probeTimeout.ProbeTimeout).
 		Wait(probeTimeout.Interval).
+		Context(types.AbortContext()).
 		TryWithTimeout(func(attempt uint) error {
 			//defining the gvr for the requested resource
 			gvr := schema.GroupVersionResource{
@@ -91,7 +79,17 @@ func triggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 
 			switch strings.ToLower(inputs.Operation) {
 			case "create":
-				if err = createResource(probe, gvr, clients); err != nil {
+				if err = createResource(probe, gvr, clients, resultDetails); err != nil {
+					log.Errorf("the %v k8s probe has Failed, err: %v", probe.Name, err)
+					return err
+				}
+			case "patch":
+				if err = patchResource(probe, gvr, parsedResourceNames, clients, resultDetails); err != nil {
+					log.Errorf("the %v k8s probe has Failed, err: %v", probe.Name, err)
+					return err
+				}
+			case "update":
+				if err = updateResource(probe, gvr, clients, resultDetails); err != nil {
 					log.Errorf("the %v k8s probe has Failed, err: %v", probe.Name, err)
 					return err
 				}
@@ -101,7 +99,12 @@ func triggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 					return err
 				}
 			case "present":
-				if err = resourcesPresent(probe, gvr, parsedResourceNames, clients); err != nil {
+				resources, err := resourcesPresent(probe, gvr, parsedResourceNames, clients)
+				if err != nil {
+					log.Errorf("the %v k8s probe has Failed, err: %v", probe.Name, err)
+					return err
+				}
+				if err = validateK8sResourceFields(probe, resultDetails, resources); err != nil {
 					log.Errorf("the %v k8s probe has Failed, err: %v", probe.Name, err)
 					return err
 				}
@@ -123,6 +126,38 @@ func triggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 	return nil
 }
 
+// parseK8sProbeInputs parses the templated selectors and resource names of the k8s probe
+// it updates the inputs in place and returns the list of resource names
+func parseK8sProbeInputs(inputs *v1alpha1.K8sProbeInputs, resultDetails *types.ResultDetails) ([]string, error) {
+	var err error
+
+	// It parses the templated command and return normal string
+	// if command doesn't have template, it will return the same command
+	inputs.FieldSelector, err = parseCommand(inputs.FieldSelector, resultDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs.LabelSelector, err = parseCommand(inputs.LabelSelector, resultDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs.ResourceNames, err = parseCommand(inputs.ResourceNames, resultDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedResourceNames := []string{}
+	if inputs.ResourceNames != "" {
+		parsedResourceNames = strings.Split(inputs.ResourceNames, ",")
+		for i := range parsedResourceNames {
+			parsedResourceNames[i] = strings.TrimSpace(parsedResourceNames[i])
+		}
+	}
+	return parsedResourceNames, nil
+}
+
 // triggerContinuousK8sProbe trigger the continuous k8s probes
 func triggerContinuousK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
 	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
@@ -134,9 +169,33 @@ func triggerContinuousK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.C
 		time.Sleep(probeTimeout.InitialDelay)
 	}
 
+	switch strings.ToLower(probe.K8sProbeInputs.Operation) {
+	case "present", "absent":
+		// it watches the resources instead of polling them, so the short-lived violations during chaos are not missed
+		isExperimentFailed = watchContinuousK8sProbe(probe, clients, chaosresult, chaosDetails)
+	default:
+		isExperimentFailed = pollContinuousK8sProbe(probe, clients, chaosresult, chaosDetails, probeTimeout.ProbePollingInterval)
+	}
+
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
+	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+	// and failed the experiment in the end
+	if isExperimentFailed && probe.RunProperties.StopOnFailure {
+		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("unable to patch chaosengine to stop, err: %v", err)
+		}
+	}
+}
+
+// pollContinuousK8sProbe triggers the k8s probe after every polling interval till the end of chaos
+// it returns true if the probe has failed
+func pollContinuousK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails, pollingInterval time.Duration) bool {
 	// it triggers the k8s probe for the entire duration of chaos and it fails, if any error encounter
 	// marked the error for the probes, if any
-loop:
 	for {
 		select {
 		case <-chaosDetails.ProbeContext.Ctx.Done():
@@ -146,10 +205,10 @@ loop:
 					chaosresult.ProbeDetails[index].HasProbeCompleted = true
 				}
 			}
-			break loop
+			return false
 
 		default:
-			err = triggerK8sProbe(probe, clients, chaosresult)
+			err := triggerK8sProbe(probe, clients, chaosresult)
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 			if err != nil {
 				err = addProbePhase(err, string(chaosDetails.Phase))
@@ -159,27 +218,194 @@ loop:
 						chaosresult.ProbeDetails[index].HasProbeCompleted = true
 						chaosresult.ProbeDetails[index].Status.Description = getDescription(err)
 						log.Errorf("the %v k8s probe has been Failed, err: %v", probe.Name, err)
-						isExperimentFailed = true
-						break loop
+						return true
 					}
 				}
 			}
 			// waiting for the probe polling interval
-			time.Sleep(probeTimeout.ProbePollingInterval)
+			time.Sleep(pollingInterval)
 		}
 	}
-	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
-	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
-	// and failed the experiment in the end
-	if isExperimentFailed && probe.RunProperties.StopOnFailure {
-		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
-			log.Errorf("unable to patch chaosengine to stop, err: %v", err)
+}
+
+// watchContinuousK8sProbe evaluates the present/absent operation on every change of the resources till the end of chaos
+// it returns true if the probe has failed
+func watchContinuousK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) bool {
+	err := watchK8sResources(chaosDetails.ProbeContext.Ctx, probe, clients, chaosresult)
+	for index := range chaosresult.ProbeDetails {
+		if chaosresult.ProbeDetails[index].Name == probe.Name {
+			chaosresult.ProbeDetails[index].HasProbeCompleted = true
+			if err != nil {
+				// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+				chaosresult.ProbeDetails[index].Status.Description = getDescription(err)
+				log.Errorf("the %v k8s probe has been Failed, err: %v", probe.Name, err)
+				return true
+			}
+		}
+	}
+	log.Infof("Stopping %s continuous Probe", probe.Name)
+	return false
+}
+
+// watchK8sResources watches the resources with matching names or selectors until the context is done
+// the operation is evaluated on the initial list and after every watch event, it returns the first violation
+func watchK8sResources(ctx context.Context, probe v1alpha1.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+	inputs := probe.K8sProbeInputs
+
+	parsedResourceNames, err := parseK8sProbeInputs(inputs, resultDetails)
+	if err != nil {
+		return err
+	}
+
+	gvr := schema.GroupVersionResource{
+		Group:    inputs.Group,
+		Version:  inputs.Version,
+		Resource: inputs.Resource,
+	}
+	resourceClient := clients.DynamicClient.Resource(gvr).Namespace(inputs.Namespace)
+
+	// resource name has higher priority, the resources are filtered by name in that case
+	listOptions := v1.ListOptions{}
+	if len(parsedResourceNames) == 0 {
+		listOptions.LabelSelector = inputs.LabelSelector
+		listOptions.FieldSelector = inputs.FieldSelector
+	}
+
+	resources := map[string]unstructured.Unstructured{}
+	resourceVersion := ""
+	for ctx.Err() == nil {
+		if resourceVersion == "" {
+			resourceList, err := resourceClient.List(ctx, listOptions)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to list the resources with matching selector, err: %v", err)}
+			}
+			resources = map[string]unstructured.Unstructured{}
+			for _, resource := range resourceList.Items {
+				if isK8sResourceSelected(resource, parsedResourceNames) {
+					resources[resource.GetNamespace()+"/"+resource.GetName()] = resource
+				}
+			}
+			if err := evaluateK8sResources(probe, resultDetails, parsedResourceNames, resources); err != nil {
+				return err
+			}
+			resourceVersion = resourceList.GetResourceVersion()
+		}
+
+		watchOptions := listOptions
+		watchOptions.ResourceVersion = resourceVersion
+		watchOptions.AllowWatchBookmarks = true
+		watcher, err := resourceClient.Watch(ctx, watchOptions)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to watch the resources with matching selector, err: %v", err)}
+		}
+		if resourceVersion, err = consumeK8sWatchEvents(ctx, watcher, probe, resultDetails, parsedResourceNames, resources, resourceVersion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// consumeK8sWatchEvents updates the resources from the watch events and evaluates the operation after every change
+// it returns the last observed resource version to resume the watch, which is empty if the resources need to be listed again
+func consumeK8sWatchEvents(ctx context.Context, watcher watch.Interface, probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, parsedResourceNames []string, resources map[string]unstructured.Unstructured, resourceVersion string) (string, error) {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				// the watch has been closed by the server, resume it from the last observed resource version
+				return resourceVersion, nil
+			}
+			if event.Type == watch.Error {
+				// the resource version might be too old, list the resources again
+				log.Warnf("the %v k8s probe watch has been expired, err: %v", probe.Name, k8serrors.FromObject(event.Object))
+				return "", nil
+			}
+			resource, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			resourceVersion = resource.GetResourceVersion()
+
+			key := resource.GetNamespace() + "/" + resource.GetName()
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				if !isK8sResourceSelected(*resource, parsedResourceNames) {
+					continue
+				}
+				resources[key] = *resource
+			case watch.Deleted:
+				delete(resources, key)
+			default:
+				continue
+			}
+			if err := evaluateK8sResources(probe, resultDetails, parsedResourceNames, resources); err != nil {
+				return resourceVersion, err
+			}
+		}
+	}
+}
+
+// isK8sResourceSelected checks whether the resource is one of the given resource names
+// all the resources are selected if the resource names are not provided
+func isK8sResourceSelected(resource unstructured.Unstructured, parsedResourceNames []string) bool {
+	if len(parsedResourceNames) == 0 {
+		return true
+	}
+	for _, name := range parsedResourceNames {
+		if resource.GetName() == name {
+			return true
 		}
 	}
+	return false
+}
+
+// evaluateK8sResources evaluates the present/absent operation on the current state of the watched resources
+func evaluateK8sResources(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, parsedResourceNames []string, resources map[string]unstructured.Unstructured) error {
+	present := map[string]bool{}
+	items := []unstructured.Unstructured{}
+	for _, resource := range resources {
+		present[resource.GetName()] = true
+		items = append(items, resource)
+	}
+
+	switch strings.ToLower(probe.K8sProbeInputs.Operation) {
+	case "present":
+		for _, res := range parsedResourceNames {
+			if !present[res] {
+				return cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("resource '%v' is expected to be present but it doesn't exist", res)}
+			}
+		}
+		if len(items) == 0 {
+			return cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("no resource found with provided {labelSelectors: %s, fieldSelectors: %s} selectors", probe.K8sProbeInputs.LabelSelector, probe.K8sProbeInputs.FieldSelector)}
+		}
+		return validateK8sResourceFields(probe, resultDetails, items)
+	case "absent":
+		for _, res := range parsedResourceNames {
+			if present[res] {
+				return cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("resource '%v' exists but it is expected to be absent", res)}
+			}
+		}
+		if len(items) != 0 {
+			return cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("resource with {labelSelectors: %s, fieldSelectors: %s} should not exists, found %v resources with matching selectors", probe.K8sProbeInputs.LabelSelector, probe.K8sProbeInputs.FieldSelector, len(items))}
+		}
+	}
+	return nil
 }
 
 // createResource creates the resource from the data provided inside data field
-func createResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, clients clients.ClientSets) error {
+func createResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
 	decUnstructured := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
 	// Decode YAML manifest into unstructured.Unstructured
 	data := &unstructured.Unstructured{}
@@ -187,20 +413,227 @@ func createResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResou
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: err.Error()}
 	}
-	_, err := clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace).Create(context.Background(), data, v1.CreateOptions{})
+	resource, err := clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace).Create(context.Background(), data, v1.CreateOptions{})
 
 	if err != nil {
 		return cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: err.Error()}
 	}
+	trackK8sResource(resultDetails, probe.Name, gvr, resource.GetNamespace(), resource.GetName(), nil, resource)
+	return nil
+}
+
+// patchResource patches the resources with matching names or selectors with the patch provided inside data field
+func patchResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, parsedResourceNames []string, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+	patchType, err := getK8sPatchType(probe.Name, resultDetails)
+	if err != nil {
+		return err
+	}
+	// the patch can be provided in yaml or json format
+	data, err := utilyaml.ToJSON([]byte(probe.Data))
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to parse the patch, err: %v", err)}
+	}
+
+	resources, err := resourcesPresent(probe, gvr, parsedResourceNames, clients)
+	if err != nil {
+		return err
+	}
+	for index := range resources {
+		modified, err := clients.DynamicClient.Resource(gvr).Namespace(resources[index].GetNamespace()).Patch(context.Background(), resources[index].GetName(), patchType, data, v1.PatchOptions{})
+		if err != nil {
+			return cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to patch the resource '%v', err: %v", resources[index].GetName(), err)}
+		}
+		trackK8sResource(resultDetails, probe.Name, gvr, resources[index].GetNamespace(), resources[index].GetName(), &resources[index], modified)
+	}
+	return nil
+}
+
+// updateResource replaces the existing resource with the manifest provided inside data field
+func updateResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+	decUnstructured := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+	// Decode YAML manifest into unstructured.Unstructured
+	data := &unstructured.Unstructured{}
+	if _, _, err := decUnstructured.Decode([]byte(probe.Data), nil, data); err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: err.Error()}
+	}
+
+	resourceClient := clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace)
+	resource, err := resourceClient.Get(context.Background(), data.GetName(), v1.GetOptions{})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to get the resource with name %v, err: %v", data.GetName(), err)}
+	}
+
+	// the update is applied on the latest version of the resource, if the version is not provided inside the manifest
+	if data.GetResourceVersion() == "" {
+		data.SetResourceVersion(resource.GetResourceVersion())
+	}
+	modified, err := resourceClient.Update(context.Background(), data, v1.UpdateOptions{})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to update the resource '%v', err: %v", data.GetName(), err)}
+	}
+	trackK8sResource(resultDetails, probe.Name, gvr, resource.GetNamespace(), resource.GetName(), resource, modified)
+	return nil
+}
+
+// getK8sPatchType returns the patch type used by the patch operation
+func getK8sPatchType(probeName string, resultDetails *types.ResultDetails) (k8stypes.PatchType, error) {
+	patchType := "merge"
+	if inputs := getProbeInputs(probeName, resultDetails.ProbeDetails).K8sProbeInputs; inputs != nil && inputs.PatchType != "" {
+		patchType = inputs.PatchType
+	}
+
+	switch strings.ToLower(patchType) {
+	case "merge":
+		return k8stypes.MergePatchType, nil
+	case "strategic":
+		return k8stypes.StrategicMergePatchType, nil
+	default:
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("patch type '%s' not supported in the k8s probe", patchType)}
+	}
+}
+
+// trackK8sResource records the resource created or modified by the probe, so that it can be restored at the end of the experiment
+// only the state before the first modification is recorded as original, the state after the last modification is recorded as modified
+func trackK8sResource(resultDetails *types.ResultDetails, probeName string, gvr schema.GroupVersionResource, namespace, name string, original, modified *unstructured.Unstructured) {
+	probe := getProbeByName(probeName, resultDetails.ProbeDetails)
+	if probe == nil {
+		return
+	}
+	if modified != nil {
+		modified = modified.DeepCopy()
+	}
+	for index := range probe.K8sResources {
+		resource := &probe.K8sResources[index]
+		if resource.GVR == gvr && resource.Namespace == namespace && resource.Name == name {
+			resource.Modified = modified
+			return
+		}
+	}
+	if original != nil {
+		original = original.DeepCopy()
+	}
+	probe.K8sResources = append(probe.K8sResources, types.K8sProbeResource{GVR: gvr, Namespace: namespace, Name: name, Original: original, Modified: modified})
+}
+
+// restoreK8sProbeResources deletes the resources created and restores the resources modified by the k8s probes
+// the resources are restored in the reverse order of their modifications
+// the probe is marked as failed, if any of its resources can't be restored
+func restoreK8sProbeResources(resultDetails *types.ResultDetails, clients clients.ClientSets) error {
+	var restoreErrors []string
+	for i := len(resultDetails.ProbeDetails) - 1; i >= 0; i-- {
+		probe := resultDetails.ProbeDetails[i]
+		for j := len(probe.K8sResources) - 1; j >= 0; j-- {
+			resource := probe.K8sResources[j]
+			if err := restoreK8sResource(resource, clients); err != nil {
+				log.Errorf("unable to restore the '%v' %v resource of %v k8s probe, err: %v", resource.Name, resource.GVR.Resource, probe.Name, err)
+				reason := fmt.Sprintf("unable to restore the '%v' %v resource, err: %v", resource.Name, resource.GVR.Resource, err)
+				failProbeOnRestore(resultDetails, probe, reason)
+				restoreErrors = append(restoreErrors, cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: reason}.Error())
+				continue
+			}
+			log.Infof("[Cleanup]: Restored the '%v' %v resource of %v k8s probe", resource.Name, resource.GVR.Resource, probe.Name)
+		}
+		probe.K8sResources = nil
+	}
+	if len(restoreErrors) != 0 {
+		return cerrors.PreserveError{ErrString: fmt.Sprintf("[%s]", strings.Join(restoreErrors, ","))}
+	}
 	return nil
 }
 
+// failProbeOnRestore marks the probe as failed, as the resources modified by the probe can't be restored
+// the passed probe is excluded from the passed probe count
+func failProbeOnRestore(resultDetails *types.ResultDetails, probe *types.ProbeDetails, reason string) {
+	if probe.Status.Verdict == v1alpha1.ProbeVerdictPassed {
+		resultDetails.PassedProbeCount--
+	}
+	probe.Status.Verdict = v1alpha1.ProbeVerdictFailed
+	probe.Status.Description = reason
+}
+
+// restoreK8sResource deletes the created resource or reverts the modified resource to its original state
+// the fields changed by the probe are reverted via the merge patch, so that the changes made by others (e.g. controllers) are preserved
+func restoreK8sResource(resource types.K8sProbeResource, clients clients.ClientSets) error {
+	resourceClient := clients.DynamicClient.Resource(resource.GVR).Namespace(resource.Namespace)
+
+	if resource.Original == nil {
+		if err := resourceClient.Delete(context.Background(), resource.Name, v1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	if resource.Modified == nil {
+		return nil
+	}
+
+	patch, err := getK8sRestorePatch(resource.Original, resource.Modified)
+	if err != nil || patch == nil {
+		return err
+	}
+	// ignore not found error, the resource has been deleted after the modification
+	if _, err := resourceClient.Patch(context.Background(), resource.Name, k8stypes.MergePatchType, patch, v1.PatchOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// k8sServerFields contains the metadata fields managed by the api server, these are never restored
+var k8sServerFields = []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink", "deletionTimestamp", "deletionGracePeriodSeconds"}
+
+// getK8sRestorePatch returns the merge patch which reverts the modified resource to the original state
+// the status and the server managed metadata are excluded, it returns nil if there is nothing to revert
+func getK8sRestorePatch(original, modified *unstructured.Unstructured) ([]byte, error) {
+	from, to := modified.DeepCopy().Object, original.DeepCopy().Object
+	for _, object := range []map[string]interface{}{from, to} {
+		delete(object, "status")
+		for _, field := range k8sServerFields {
+			unstructured.RemoveNestedField(object, "metadata", field)
+		}
+	}
+
+	patch := createMergePatch(from, to)
+	if len(patch) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(patch)
+}
+
+// createMergePatch returns the json merge patch (RFC 7386) which converts the from object into the to object
+func createMergePatch(from, to map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for key, toValue := range to {
+		fromValue, ok := from[key]
+		if !ok {
+			patch[key] = toValue
+			continue
+		}
+		toMap, isToMap := toValue.(map[string]interface{})
+		fromMap, isFromMap := fromValue.(map[string]interface{})
+		if isToMap && isFromMap {
+			if nested := createMergePatch(fromMap, toMap); len(nested) != 0 {
+				patch[key] = nested
+			}
+			continue
+		}
+		if !reflect.DeepEqual(fromValue, toValue) {
+			patch[key] = toValue
+		}
+	}
+	// the fields added by the modification are removed
+	for key := range from {
+		if _, ok := to[key]; !ok {
+			patch[key] = nil
+		}
+	}
+	return patch
+}
+
 // deleteResource deletes the resource with matching label & field selector
 func deleteResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, parsedResourceNames []string, clients clients.ClientSets) error {
 	// resource name has higher priority
 	if len(parsedResourceNames) > 0 {
 		// check if all resources are available
-		if err := areResourcesWithNamePresent(probe, gvr, parsedResourceNames, clients); err != nil {
+		if _, err := areResourcesWithNamePresent(probe, gvr, parsedResourceNames, clients); err != nil {
 			return err
 		}
 		// delete resources
@@ -229,40 +662,85 @@ func deleteResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResou
 	return nil
 }
 
-func resourcesPresent(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, parsedResourceNames []string, clients clients.ClientSets) error {
+// resourcesPresent returns the resources with matching names or selectors
+func resourcesPresent(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, parsedResourceNames []string, clients clients.ClientSets) ([]unstructured.Unstructured, error) {
 	// resource name has higher priority
 	if len(parsedResourceNames) > 0 {
 		// check if all resources are available
-		if err := areResourcesWithNamePresent(probe, gvr, parsedResourceNames, clients); err != nil {
-			return err
-		}
-	} else {
-		resourceList, err := clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace).List(context.Background(), v1.ListOptions{
-			FieldSelector: probe.K8sProbeInputs.FieldSelector,
-			LabelSelector: probe.K8sProbeInputs.LabelSelector,
-		})
-		if err != nil {
-			log.Errorf("the %v k8s probe has Failed, err: %v", probe.Name, err)
-			return cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to list the resources with matching selector, err: %v", err)}
-		} else if len(resourceList.Items) == 0 {
-			return cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("no resource found with provided {labelSelectors: %s, fieldSelectors: %s} selectors", probe.K8sProbeInputs.LabelSelector, probe.K8sProbeInputs.FieldSelector)}
-		}
+		return areResourcesWithNamePresent(probe, gvr, parsedResourceNames, clients)
 	}
-	return nil
+
+	resourceList, err := clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace).List(context.Background(), v1.ListOptions{
+		FieldSelector: probe.K8sProbeInputs.FieldSelector,
+		LabelSelector: probe.K8sProbeInputs.LabelSelector,
+	})
+	if err != nil {
+		log.Errorf("the %v k8s probe has Failed, err: %v", probe.Name, err)
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to list the resources with matching selector, err: %v", err)}
+	} else if len(resourceList.Items) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("no resource found with provided {labelSelectors: %s, fieldSelectors: %s} selectors", probe.K8sProbeInputs.LabelSelector, probe.K8sProbeInputs.FieldSelector)}
+	}
+	return resourceList.Items, nil
 }
 
-func areResourcesWithNamePresent(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, parsedResourceNames []string, clients clients.ClientSets) error {
+func areResourcesWithNamePresent(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, parsedResourceNames []string, clients clients.ClientSets) ([]unstructured.Unstructured, error) {
+	resources := []unstructured.Unstructured{}
 	for _, res := range parsedResourceNames {
 		resource, err := clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace).Get(context.Background(), res, v1.GetOptions{})
 		if err != nil {
-			return cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to get the resources with name %v, err: %v", res, err)}
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to get the resources with name %v, err: %v", res, err)}
 		} else if resource == nil {
-			return cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to get the resources with name %v", res)}
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to get the resources with name %v", res)}
+		}
+		resources = append(resources, *resource)
+	}
+	return resources, nil
+}
+
+// validateK8sResourceFields verify the fields of all the resources against the field checks
+func validateK8sResourceFields(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, resources []unstructured.Unstructured) error {
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).K8sProbeInputs
+	if inputs == nil || len(inputs.FieldChecks) == 0 {
+		return nil
+	}
+
+	rc := getAndIncrementRunCount(resultDetails, probe.Name)
+	for index := range resources {
+		for _, check := range inputs.FieldChecks {
+			value, err := getJSONPathValue(resources[index].Object, check.JSONPath, probe.Name, cerrors.FailureTypeK8sProbe)
+			if err != nil {
+				return cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("resource '%s': %s", resources[index].GetName(), getDescription(err))}
+			}
+			if err := compareK8sFieldValue(probe, check.Comparator, value, rc); err != nil {
+				if cerrors.GetErrorType(err) != cerrors.FailureTypeK8sProbe {
+					return err
+				}
+				return cerrors.Error{ErrorCode: cerrors.FailureTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("The field '%s' of resource '%s' didn't match. %s", check.JSONPath, resources[index].GetName(), getDescription(err))}
+			}
 		}
 	}
 	return nil
 }
 
+// compareK8sFieldValue compares the field value with the expected value based on the comparator type
+func compareK8sFieldValue(probe v1alpha1.ProbeAttributes, comparator v1alpha1.ComparatorInfo, value string, rc int) error {
+	compare := cmp.RunCount(rc).
+		FirstValue(value).
+		SecondValue(comparator.Value).
+		Criteria(comparator.Criteria).
+		ProbeName(probe.Name).
+		ProbeVerbosity(probe.RunProperties.Verbosity)
+
+	comparatorType := comparator.Type
+	if comparatorType == "" {
+		comparatorType = "string"
+	}
+	if !cmp.IsSupportedType(comparatorType) {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeK8sProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("comparator type '%s' not supported in the k8s probe", comparator.Type)}
+	}
+	return compare.Compare(comparatorType, cerrors.FailureTypeK8sProbe)
+}
+
 func resourcesAbsent(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, parsedResourceNames []string, clients clients.ClientSets) error {
 	// resource name has higher priority
 	if len(parsedResourceNames) > 0 {
@@ -460,6 +938,10 @@ loop:
 			}
 		}
 	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
 	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
 	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
 	// and failed the experiment in the end
@@ -0,0 +1,138 @@
+package probe
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+var configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// getConfigMap returns the configmap with the given data and server managed fields
+func getConfigMap(resourceVersion string, data map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":              "nginx",
+			"namespace":         "default",
+			"uid":               "4f9c1e2a",
+			"resourceVersion":   resourceVersion,
+			"creationTimestamp": "2024-03-01T10:00:00Z",
+			"managedFields":     []interface{}{map[string]interface{}{"manager": resourceVersion}},
+		},
+		"data": data,
+	}}
+}
+
+func TestGetK8sRestorePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original map[string]interface{}
+		modified map[string]interface{}
+		want     string
+	}{
+		{
+			name:     "changed field",
+			original: map[string]interface{}{"mode": "normal", "replicas": "3"},
+			modified: map[string]interface{}{"mode": "chaos", "replicas": "3"},
+			want:     `{"data":{"mode":"normal"}}`,
+		},
+		{
+			name:     "added field",
+			original: map[string]interface{}{"mode": "normal"},
+			modified: map[string]interface{}{"mode": "normal", "delay": "5s"},
+			want:     `{"data":{"delay":null}}`,
+		},
+		{
+			name:     "removed field",
+			original: map[string]interface{}{"mode": "normal", "delay": "5s"},
+			modified: map[string]interface{}{"mode": "normal"},
+			want:     `{"data":{"delay":"5s"}}`,
+		},
+		{
+			name:     "unchanged",
+			original: map[string]interface{}{"mode": "normal"},
+			modified: map[string]interface{}{"mode": "normal"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the server managed fields and status differ between the versions, these should never be restored
+			original := getConfigMap("100", tt.original)
+			modified := getConfigMap("101", tt.modified)
+			modified.Object["status"] = map[string]interface{}{"phase": "Active"}
+			require.NoError(t, unstructured.SetNestedField(modified.Object, int64(2), "metadata", "generation"))
+
+			patch, err := getK8sRestorePatch(original, modified)
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.Nil(t, patch)
+				return
+			}
+			assert.JSONEq(t, tt.want, string(patch))
+		})
+	}
+}
+
+func TestRestoreK8sProbeResources(t *testing.T) {
+	var patches []string
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v1/namespaces/default/configmaps/nginx" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "application/merge-patch+json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		patches = append(patches, string(body))
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(getConfigMap("102", map[string]interface{}{"mode": "normal"}).Object)
+	}))
+	defer server.Close()
+	clientSets := clients.ClientSets{DynamicClient: dynamic.NewForConfigOrDie(&rest.Config{Host: server.URL})}
+
+	getResultDetails := func() *types.ResultDetails {
+		probe := &types.ProbeDetails{Name: "k8s-patch", Type: "k8sProbe", Mode: "SOT", Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictPassed}}
+		resultDetails := &types.ResultDetails{ProbeDetails: []*types.ProbeDetails{probe}, PassedProbeCount: 1}
+		trackK8sResource(resultDetails, probe.Name, configMapGVR, "default", "nginx", getConfigMap("100", map[string]interface{}{"mode": "normal"}), getConfigMap("101", map[string]interface{}{"mode": "canary"}))
+		// only the original state of the first modification is kept
+		trackK8sResource(resultDetails, probe.Name, configMapGVR, "default", "nginx", getConfigMap("101", map[string]interface{}{"mode": "canary"}), getConfigMap("102", map[string]interface{}{"mode": "chaos"}))
+		return resultDetails
+	}
+
+	t.Run("restored", func(t *testing.T) {
+		resultDetails := getResultDetails()
+		require.Len(t, resultDetails.ProbeDetails[0].K8sResources, 1)
+		require.NoError(t, restoreK8sProbeResources(resultDetails, clientSets))
+		assert.Equal(t, []string{`{"data":{"mode":"normal"}}`}, patches)
+		assert.Empty(t, resultDetails.ProbeDetails[0].K8sResources)
+		assert.Equal(t, v1alpha1.ProbeVerdictPassed, resultDetails.ProbeDetails[0].Status.Verdict)
+		assert.Equal(t, 1, resultDetails.PassedProbeCount)
+	})
+
+	t.Run("restore failed", func(t *testing.T) {
+		statusCode = http.StatusForbidden
+		resultDetails := getResultDetails()
+		err := restoreK8sProbeResources(resultDetails, clientSets)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), string(cerrors.FailureTypeK8sProbe))
+		assert.Equal(t, v1alpha1.ProbeVerdictFailed, resultDetails.ProbeDetails[0].Status.Verdict)
+		assert.Contains(t, resultDetails.ProbeDetails[0].Status.Description, "unable to restore the 'nginx' configmaps resource")
+		assert.Equal(t, 0, resultDetails.PassedProbeCount)
+	})
+}
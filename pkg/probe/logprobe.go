@@ -0,0 +1,459 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/math"
+	cmp "github.com/figwood/litmus-go/pkg/probe/comparator"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// prepareLogProbe contains the steps to prepare the log probe
+// log probe can be used to add the probe which will scan the logs of the pods during chaos and check the count of the matched lines
+func prepareLogProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+
+	if getProbeInputs(probe.Name, resultDetails.ProbeDetails).LogProbeInputs == nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeLogProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "logProbe/inputs are required for the log probe"}
+	}
+
+	// the logs are scanned over the chaos window, so the probe is supported only in continuous and onchaos modes
+	switch strings.ToLower(probe.Mode) {
+	case "continuous", "onchaos":
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeLogProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("mode '%s' not supported in the log probe", probe.Mode)}
+	}
+
+	switch strings.ToLower(phase) {
+	case "prechaos":
+		preChaosLogProbe(probe, resultDetails, clients, chaosDetails)
+	case "postchaos":
+		if err := postChaosLogProbe(probe, resultDetails, chaosDetails.Delay, chaosDetails.Timeout); err != nil {
+			return err
+		}
+	case "duringchaos":
+		onChaosLogProbe(probe, resultDetails, clients, chaosDetails)
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeLogProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("phase '%s' not supported in the log probe", phase)}
+	}
+	return nil
+}
+
+// podSelector contains the selectors of the pods, e.g. the pods whose logs are scanned
+type podSelector struct {
+	namespace     string
+	labelSelector string
+	fieldSelector string
+}
+
+// logScanner follows the logs of the selected pods and counts the lines matching the patterns
+type logScanner struct {
+	probeName    string
+	clients      clients.ClientSets
+	container    string
+	since        v1.Time
+	patterns     []*regexp.Regexp
+	maxArtifacts int
+
+	mutex     sync.Mutex
+	wg        sync.WaitGroup
+	counts    []int
+	artifacts [][]string
+	streams   map[string]bool
+	err       error
+}
+
+// newLogScanner returns the scanner for the given inputs, which scans the logs since the given time
+func newLogScanner(probeName string, inputs *types.LogProbeInputs, clients clients.ClientSets, since time.Time) (*logScanner, error) {
+	if len(inputs.Patterns) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeLogProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "[Probe]: At least one pattern is required"}
+	}
+
+	scanner := &logScanner{
+		probeName:    probeName,
+		clients:      clients,
+		container:    inputs.Container,
+		since:        v1.NewTime(since),
+		maxArtifacts: inputs.MaxArtifacts,
+		counts:       make([]int, len(inputs.Patterns)),
+		artifacts:    make([][]string, len(inputs.Patterns)),
+		streams:      map[string]bool{},
+	}
+	if scanner.maxArtifacts == 0 {
+		scanner.maxArtifacts = 10
+	}
+	for _, pattern := range inputs.Patterns {
+		re, err := regexp.Compile(pattern.Regex)
+		if err != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeLogProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("The probe regex '%s' is not a valid expression, err: %v", pattern.Regex, err)}
+		}
+		scanner.patterns = append(scanner.patterns, re)
+	}
+	return scanner, nil
+}
+
+// scan follows the logs of the pods matching the selectors until the context is done
+// it also follows the pods created during the scan, e.g. the pods replaced by the chaos
+func (s *logScanner) scan(ctx context.Context, selectors []podSelector) error {
+	var watchers sync.WaitGroup
+	for _, selector := range selectors {
+		watchers.Add(1)
+		go func(selector podSelector) {
+			defer watchers.Done()
+			if err := s.watchPods(ctx, selector); err != nil {
+				s.mutex.Lock()
+				s.err = err
+				s.mutex.Unlock()
+			}
+		}(selector)
+	}
+	watchers.Wait()
+	// the log streams are closed along with the context
+	s.wg.Wait()
+	return s.err
+}
+
+// watchPods follows the logs of the existing pods and the pods created afterwards
+func (s *logScanner) watchPods(ctx context.Context, selector podSelector) error {
+	listOptions := v1.ListOptions{
+		LabelSelector: selector.labelSelector,
+		FieldSelector: selector.fieldSelector,
+	}
+
+	for ctx.Err() == nil {
+		pods, err := s.clients.KubeClient.CoreV1().Pods(selector.namespace).List(ctx, listOptions)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypeLogProbe, Target: fmt.Sprintf("{name: %v}", s.probeName), Reason: fmt.Sprintf("unable to list the pods with {labelSelectors: %s, fieldSelectors: %s} selectors in %s namespace, err: %v", selector.labelSelector, selector.fieldSelector, selector.namespace, err)}
+		}
+		for index := range pods.Items {
+			s.followPod(ctx, &pods.Items[index])
+		}
+
+		watchOptions := listOptions
+		watchOptions.ResourceVersion = pods.ResourceVersion
+		watcher, err := s.clients.KubeClient.CoreV1().Pods(selector.namespace).Watch(ctx, watchOptions)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.Warnf("unable to watch the pods of %v log probe, err: %v", s.probeName, err)
+			time.Sleep(time.Second)
+			continue
+		}
+		s.consumePodEvents(ctx, watcher)
+	}
+	return nil
+}
+
+// consumePodEvents follows the logs of the pods present inside the watch events
+// it returns if the context is done or the watch is closed
+func (s *logScanner) consumePodEvents(ctx context.Context, watcher watch.Interface) {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.ResultChan():
+			if !ok || event.Type == watch.Error {
+				return
+			}
+			if pod, ok := event.Object.(*corev1.Pod); ok && (event.Type == watch.Added || event.Type == watch.Modified) {
+				s.followPod(ctx, pod)
+			}
+		}
+	}
+}
+
+// followPod starts the log streams of the running containers of the pod
+// the restarted containers are followed again, as the logs of every container instance are streamed separately
+func (s *logScanner) followPod(ctx context.Context, pod *corev1.Pod) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running == nil || (s.container != "" && status.Name != s.container) {
+			continue
+		}
+		key := string(pod.UID) + "/" + status.Name + "/" + strconv.Itoa(int(status.RestartCount))
+
+		s.mutex.Lock()
+		if s.streams[key] {
+			s.mutex.Unlock()
+			continue
+		}
+		s.streams[key] = true
+		s.mutex.Unlock()
+
+		s.wg.Add(1)
+		go func(namespace, podName, containerName string) {
+			defer s.wg.Done()
+			if err := s.streamLogs(ctx, namespace, podName, containerName); err != nil && ctx.Err() == nil {
+				log.Warnf("unable to stream the logs of %v/%v container for %v log probe, err: %v", podName, containerName, s.probeName, err)
+			}
+		}(pod.Namespace, pod.Name, status.Name)
+	}
+}
+
+// streamLogs follows the logs of the container since the start time and matches each line against the patterns
+func (s *logScanner) streamLogs(ctx context.Context, namespace, podName, containerName string) error {
+	log.Infof("[Probe]: Streaming the logs of %v/%v container for %v log probe", podName, containerName, s.probeName)
+
+	stream, err := s.clients.KubeClient.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: containerName,
+		Follow:    true,
+		SinceTime: &s.since,
+	}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		s.match(podName, containerName, scanner.Text())
+	}
+	return scanner.Err()
+}
+
+// match counts the line for all the matching patterns and records it as artifact
+func (s *logScanner) match(podName, containerName, line string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for index, re := range s.patterns {
+		if !re.MatchString(line) {
+			continue
+		}
+		s.counts[index]++
+		if len(s.artifacts[index]) < s.maxArtifacts {
+			s.artifacts[index] = append(s.artifacts[index], fmt.Sprintf("%s/%s: %s", podName, containerName, line))
+		}
+	}
+}
+
+// evaluate verify the count of the matched lines of every pattern against its threshold
+// it returns the summary of the matched lines as description
+func (s *logScanner) evaluate(probe v1alpha1.ProbeAttributes, inputs *types.LogProbeInputs) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	summary := []string{}
+	for index, pattern := range inputs.Patterns {
+		count := v1alpha1.ComparatorInfo{Criteria: "<=", Value: "0"}
+		if pattern.Count != nil {
+			count = *pattern.Count
+		}
+
+		if err := cmp.RunCount(1).
+			FirstValue(strconv.Itoa(s.counts[index])).
+			SecondValue(count.Value).
+			Criteria(count.Criteria).
+			ProbeName(probe.Name).
+			ProbeVerbosity(probe.RunProperties.Verbosity).
+			CompareInt(cerrors.FailureTypeLogProbe); err != nil {
+			return "", cerrors.Error{ErrorCode: cerrors.FailureTypeLogProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("The pattern '%s' matched %d lines. %s", pattern.Regex, s.counts[index], getDescription(err))}
+		}
+		summary = append(summary, fmt.Sprintf("'%s': %d", pattern.Regex, s.counts[index]))
+	}
+	return fmt.Sprintf("The logs did match the expected count of lines. Matched lines: {%s}", strings.Join(summary, ", ")), nil
+}
+
+// getArtifacts returns the recorded lines of all the patterns
+func (s *logScanner) getArtifacts() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	artifacts := []string{}
+	for index := range s.artifacts {
+		artifacts = append(artifacts, s.artifacts[index]...)
+	}
+	return artifacts
+}
+
+// getPodSelectors returns the selectors of the pods for the given namespace and label selector
+// it derives the selectors from the chaos targets(appinfo), if label selector is not provided
+func getPodSelectors(namespace, labelSelector string, chaosDetails *types.ChaosDetails) []podSelector {
+	if labelSelector != "" {
+		return []podSelector{{namespace: namespace, labelSelector: labelSelector}}
+	}
+
+	selectors := []podSelector{}
+	for _, app := range chaosDetails.AppDetail {
+		appNamespace := app.Namespace
+		if namespace != "" {
+			appNamespace = namespace
+		}
+		for _, label := range app.Labels {
+			selectors = append(selectors, podSelector{namespace: appNamespace, labelSelector: label})
+		}
+		// the names can be used as selector only for the pods, the workloads are selected via labels
+		if strings.ToLower(app.Kind) == "pod" {
+			for _, name := range app.Names {
+				selectors = append(selectors, podSelector{namespace: appNamespace, fieldSelector: "metadata.name=" + name})
+			}
+		}
+	}
+	return selectors
+}
+
+// runLogProbe scans the logs until the context is done and evaluate the matched lines
+// it records the verdict along with the matched lines inside the probe details
+func runLogProbe(ctx context.Context, probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	inputs := getProbeInputs(probe.Name, chaosresult.ProbeDetails).LogProbeInputs
+
+	description, artifacts, err := scanLogs(ctx, probe, inputs, clients, chaosDetails)
+	for index := range chaosresult.ProbeDetails {
+		if chaosresult.ProbeDetails[index].Name == probe.Name {
+			chaosresult.ProbeDetails[index].Artifacts = artifacts
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+				description = getDescription(err)
+				log.Errorf("The %v log probe has been Failed, err: %v", probe.Name, err)
+			}
+			chaosresult.ProbeDetails[index].Status.Description = description
+			chaosresult.ProbeDetails[index].HasProbeCompleted = true
+		}
+	}
+	for _, artifact := range artifacts {
+		log.Infof("[Probe]: The %v log probe matched the line, %v", probe.Name, artifact)
+	}
+}
+
+// scanLogs scans the logs of the selected pods until the context is done
+// it returns the description along with the matched lines
+func scanLogs(ctx context.Context, probe v1alpha1.ProbeAttributes, inputs *types.LogProbeInputs, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (string, []string, error) {
+	selectors := getPodSelectors(inputs.Namespace, inputs.LabelSelector, chaosDetails)
+	if len(selectors) == 0 {
+		return "", nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeLogProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "[Probe]: Any one of labelSelector or chaos targets is required to select the pods"}
+	}
+
+	scanner, err := newLogScanner(probe.Name, inputs, clients, getLogSinceTime(chaosDetails))
+	if err != nil {
+		return "", nil, err
+	}
+	if err := scanner.scan(ctx, selectors); err != nil {
+		return "", scanner.getArtifacts(), err
+	}
+	description, err := scanner.evaluate(probe, inputs)
+	return description, scanner.getArtifacts(), err
+}
+
+// getLogSinceTime returns the time from which the logs are scanned
+// it is the chaos start time, so the logs emitted before the probe is started (e.g. during the initial delay) are also scanned
+// the continuous probes are started before the chaos injection, so these scan the logs from the current time
+func getLogSinceTime(chaosDetails *types.ChaosDetails) time.Time {
+	if startTime, ok := types.GetPhaseStartTime(chaosDetails, types.ChaosInjectPhase); ok {
+		return startTime
+	}
+	return time.Now()
+}
+
+// triggerContinuousLogProbe scans the logs for the entire duration of chaos
+func triggerContinuousLogProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+	}
+
+	runLogProbe(chaosDetails.ProbeContext.Ctx, probe, clients, chaosresult, chaosDetails)
+	log.Infof("Stopping %s continuous Probe", probe.Name)
+}
+
+// triggerOnChaosLogProbe scans the logs for the chaos duration
+func triggerOnChaosLogProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+
+	duration := chaosDetails.ChaosDuration
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+		duration = math.Maximum(0, duration-int(probeTimeout.InitialDelay.Seconds()))
+	}
+
+	ctx, cancel := context.WithTimeout(chaosDetails.ProbeContext.Ctx, time.Duration(duration)*time.Second)
+	defer cancel()
+
+	runLogProbe(ctx, probe, clients, chaosresult, chaosDetails)
+	log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+}
+
+// preChaosLogProbe trigger the log probe for prechaos phase
+func preChaosLogProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).LogProbeInputs
+
+	switch strings.ToLower(probe.Mode) {
+	case "continuous":
+
+		//DISPLAY THE LOG PROBE INFO
+		log.InfoWithValues("[Probe]: The log probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Namespace":      inputs.Namespace,
+			"LabelSelector":  inputs.LabelSelector,
+			"Patterns":       inputs.Patterns,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		go triggerContinuousLogProbe(probe, clients, resultDetails, chaosDetails)
+	}
+}
+
+// postChaosLogProbe trigger the log probe for postchaos phase
+func postChaosLogProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, delay int, timeout int) error {
+	switch strings.ToLower(probe.Mode) {
+	case "continuous", "onchaos":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		if err = checkForErrorInContinuousProbe(resultDetails, probe.Name, delay, timeout); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeLogProbe && cerrors.GetErrorType(err) != cerrors.FailureTypeProbeTimeout {
+			return err
+		}
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onChaosLogProbe trigger the log probe for DuringChaos phase
+func onChaosLogProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).LogProbeInputs
+
+	switch strings.ToLower(probe.Mode) {
+	case "onchaos":
+
+		//DISPLAY THE LOG PROBE INFO
+		log.InfoWithValues("[Probe]: The log probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Namespace":      inputs.Namespace,
+			"LabelSelector":  inputs.LabelSelector,
+			"Patterns":       inputs.Patterns,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+		go triggerOnChaosLogProbe(probe, clients, resultDetails, chaosDetails)
+	}
+}
@@ -0,0 +1,22 @@
+package probe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLogSinceTime(t *testing.T) {
+	chaosStartTime := time.Now().Add(-time.Minute)
+	chaosDetails := &types.ChaosDetails{PhaseTimings: []types.PhaseTiming{
+		{Phase: types.PreChaosPhase, StartTime: chaosStartTime.Add(-time.Minute)},
+		{Phase: types.ChaosInjectPhase, StartTime: chaosStartTime},
+	}}
+	assert.Equal(t, chaosStartTime, getLogSinceTime(chaosDetails))
+
+	// the chaos is not yet injected, e.g. continuous probe started in the prechaos phase
+	chaosDetails.PhaseTimings = chaosDetails.PhaseTimings[:1]
+	assert.WithinDuration(t, time.Now(), getLogSinceTime(chaosDetails), 5*time.Second)
+}
@@ -0,0 +1,92 @@
+package probe
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+)
+
+// probeOutputsKey is the key of the named outputs of all the probes inside the template data
+const probeOutputsKey = "probe"
+
+// registerProbeOutput registers the output of the probe along with the named outputs extracted from it
+// the value is registered as it is and used by the regex, the document is used by the jsonpath
+func registerProbeOutput(probe v1alpha1.ProbeAttributes, value string, document []byte, resultDetails *types.ResultDetails, errorCode cerrors.ErrorType) error {
+	outputs, err := extractProbeOutputs(probe.Name, getProbeInputs(probe.Name, resultDetails.ProbeDetails).Outputs, value, document, errorCode)
+	if err != nil {
+		return err
+	}
+
+	probes := types.ProbeArtifact{}
+	probes.ProbeArtifacts.Register = value
+	probes.ProbeArtifacts.Outputs = outputs
+	resultDetails.ProbeArtifacts[probe.Name] = probes
+	return nil
+}
+
+// extractProbeOutputs returns the named outputs extracted from the output of the probe
+func extractProbeOutputs(probeName string, outputs []types.ProbeOutput, value string, document []byte, errorCode cerrors.ErrorType) (map[string]string, error) {
+	if len(outputs) == 0 {
+		return nil, nil
+	}
+
+	var data interface{}
+	extracted := make(map[string]string, len(outputs))
+	for _, output := range outputs {
+		switch {
+		case output.JSONPath != "":
+			if data == nil {
+				if err := json.Unmarshal(document, &data); err != nil {
+					return nil, cerrors.Error{ErrorCode: errorCode, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to parse the output as json to extract the '%s' output, err: %v", output.Name, err)}
+				}
+			}
+			result, err := getJSONPathValue(data, output.JSONPath, probeName, errorCode)
+			if err != nil {
+				return nil, err
+			}
+			extracted[output.Name] = result
+		case output.Regex != "":
+			re, err := regexp.Compile(output.Regex)
+			if err != nil {
+				return nil, cerrors.Error{ErrorCode: errorCode, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("The '%s' output regex is not valid, err: %v", output.Name, err)}
+			}
+			match := re.FindStringSubmatch(value)
+			if match == nil {
+				return nil, cerrors.Error{ErrorCode: errorCode, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("The '%s' output regex '%s' didn't match the output", output.Name, output.Regex)}
+			}
+			// the first capture group is extracted if present, otherwise the complete match
+			group := 0
+			if len(match) > 1 {
+				group = 1
+			}
+			extracted[output.Name] = match[group]
+		default:
+			extracted[output.Name] = value
+		}
+		log.Infof("[Probe]: The %v probe registered the %v output", probeName, output.Name)
+	}
+	return extracted, nil
+}
+
+// getTemplateData returns the data used to parse the templated inputs of the probes
+// the raw output of a probe is referenced as {{ .<probe-name>.ProbeArtifacts.Register }}
+// and the named outputs are referenced as {{ .probe.<probe-name>.<output-name> }}
+// the names which are not valid identifiers can be referenced via index, e.g. {{ index .probe "login-api" "token" }}
+func getTemplateData(resultDetails *types.ResultDetails) map[string]interface{} {
+	data := make(map[string]interface{}, len(resultDetails.ProbeArtifacts)+1)
+	outputs := make(map[string]map[string]string, len(resultDetails.ProbeArtifacts))
+	for name, artifact := range resultDetails.ProbeArtifacts {
+		data[name] = artifact
+		outputs[name] = artifact.ProbeArtifacts.Outputs
+	}
+	// the probe with the same name takes the precedence, to keep the existing templates working
+	if _, ok := data[probeOutputsKey]; !ok {
+		data[probeOutputsKey] = outputs
+	}
+	return data
+}
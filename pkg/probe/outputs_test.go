@@ -0,0 +1,148 @@
+package probe
+
+import (
+	"testing"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractProbeOutputs(t *testing.T) {
+	const document = `{"data": {"token": "a1b2c3", "replicas": 3, "pods": [{"name": "nginx-0"}, {"name": "nginx-1"}]}}`
+	tests := []struct {
+		name    string
+		outputs []types.ProbeOutput
+		value   string
+		want    map[string]string
+		wantErr string
+	}{
+		{name: "no outputs"},
+		{
+			name:    "jsonpath",
+			outputs: []types.ProbeOutput{{Name: "token", JSONPath: "{.data.token}"}, {Name: "replicas", JSONPath: ".data.replicas"}, {Name: "pod", JSONPath: "{.data.pods[1].name}"}},
+			want:    map[string]string{"token": "a1b2c3", "replicas": "3", "pod": "nginx-1"},
+		},
+		{
+			name:    "jsonpath without match",
+			outputs: []types.ProbeOutput{{Name: "token", JSONPath: "{.data.missing}"}},
+			wantErr: "missing",
+		},
+		{
+			name:    "regex with capture group",
+			outputs: []types.ProbeOutput{{Name: "version", Regex: `nginx/(\d+\.\d+\.\d+)`}},
+			value:   "nginx version: nginx/1.21.6",
+			want:    map[string]string{"version": "1.21.6"},
+		},
+		{
+			name:    "regex with multiple capture groups",
+			outputs: []types.ProbeOutput{{Name: "major", Regex: `nginx/(\d+)\.(\d+)`}},
+			value:   "nginx version: nginx/1.21.6",
+			want:    map[string]string{"major": "1"},
+		},
+		{
+			name:    "regex with unmatched optional group",
+			outputs: []types.ProbeOutput{{Name: "suffix", Regex: `nginx/[\d.]+(-\w+)?`}},
+			value:   "nginx version: nginx/1.21.6",
+			want:    map[string]string{"suffix": ""},
+		},
+		{
+			name:    "regex without capture group",
+			outputs: []types.ProbeOutput{{Name: "version", Regex: `\d+\.\d+\.\d+`}},
+			value:   "nginx version: nginx/1.21.6",
+			want:    map[string]string{"version": "1.21.6"},
+		},
+		{
+			name:    "regex without match",
+			outputs: []types.ProbeOutput{{Name: "version", Regex: `apache/(\S+)`}},
+			value:   "nginx version: nginx/1.21.6",
+			wantErr: "didn't match the output",
+		},
+		{
+			name:    "invalid regex",
+			outputs: []types.ProbeOutput{{Name: "version", Regex: `nginx/(\d+`}},
+			value:   "nginx version: nginx/1.21.6",
+			wantErr: "regex is not valid",
+		},
+		{
+			name:    "complete output",
+			outputs: []types.ProbeOutput{{Name: "raw"}},
+			value:   "nginx version: nginx/1.21.6",
+			want:    map[string]string{"raw": "nginx version: nginx/1.21.6"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputs, err := extractProbeOutputs("nginx-probe", tt.outputs, tt.value, []byte(document), cerrors.FailureTypeCmdProbe)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, cerrors.FailureTypeCmdProbe, cerrors.GetErrorType(err))
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, outputs)
+		})
+	}
+
+	// the document is parsed only for the jsonpath outputs
+	_, err := extractProbeOutputs("nginx-probe", []types.ProbeOutput{{Name: "token", JSONPath: "{.token}"}}, "", []byte("nginx/1.21.6"), cerrors.FailureTypeCmdProbe)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to parse the output as json")
+}
+
+func TestParseCommandWithProbeOutputs(t *testing.T) {
+	resultDetails := &types.ResultDetails{ProbeArtifacts: map[string]types.ProbeArtifact{}}
+	login := types.ProbeArtifact{}
+	login.ProbeArtifacts.Register = `{"token": "a1b2c3"}`
+	login.ProbeArtifacts.Outputs = map[string]string{"token": "a1b2c3"}
+	resultDetails.ProbeArtifacts["login"] = login
+	version := types.ProbeArtifact{}
+	version.ProbeArtifacts.Register = "nginx/1.21.6"
+	version.ProbeArtifacts.Outputs = map[string]string{"version": "1.21.6"}
+	resultDetails.ProbeArtifacts["nginx-version"] = version
+
+	tests := []struct {
+		name    string
+		command string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain command", command: "curl http://nginx", want: "curl http://nginx"},
+		{name: "named output", command: "curl -H 'Authorization: Bearer {{ .probe.login.token }}' http://nginx", want: "curl -H 'Authorization: Bearer a1b2c3' http://nginx"},
+		{name: "named output of the probe with the non identifier name", command: `echo {{ index .probe "nginx-version" "version" }}`, want: "echo 1.21.6"},
+		{name: "raw output", command: `echo {{ (index . "nginx-version").ProbeArtifacts.Register }}`, want: "echo nginx/1.21.6"},
+		// the probe artifacts are rendered via html/template, the same way as the earlier releases
+		{name: "escaped raw output", command: "echo {{ .login.ProbeArtifacts.Register }}", want: "echo {&#34;token&#34;: &#34;a1b2c3&#34;}"},
+		{name: "missing output", command: "echo {{ .probe.login.missing }}", want: "echo "},
+		{name: "invalid template", command: "echo {{ .probe.login.token }", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := parseCommand(tt.command, resultDetails)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, command)
+		})
+	}
+}
+
+func TestGetTemplateData(t *testing.T) {
+	login := types.ProbeArtifact{}
+	login.ProbeArtifacts.Register = "ok"
+	login.ProbeArtifacts.Outputs = map[string]string{"token": "a1b2c3"}
+
+	data := getTemplateData(&types.ResultDetails{ProbeArtifacts: map[string]types.ProbeArtifact{"login": login}})
+	assert.Equal(t, login, data["login"])
+	assert.Equal(t, map[string]map[string]string{"login": {"token": "a1b2c3"}}, data[probeOutputsKey])
+
+	// the probe named as probe takes the precedence over the named outputs, to keep the existing templates working
+	probe := types.ProbeArtifact{}
+	probe.ProbeArtifacts.Register = "legacy"
+	data = getTemplateData(&types.ResultDetails{ProbeArtifacts: map[string]types.ProbeArtifact{"login": login, probeOutputsKey: probe}})
+	assert.Equal(t, probe, data[probeOutputsKey])
+}
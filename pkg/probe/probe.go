@@ -13,17 +13,21 @@ import (
 	"github.com/figwood/litmus-go/pkg/cerrors"
 	"github.com/figwood/litmus-go/pkg/clients"
 	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/metrics"
+	"github.com/figwood/litmus-go/pkg/tracing"
 	"github.com/figwood/litmus-go/pkg/types"
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var err error
 
 // RunProbes contains the steps to trigger the probes
-// It contains steps to trigger all three probes: k8sprobe, httpprobe, cmdprobe
-func RunProbes(chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
+// It contains steps to trigger all the probes: k8sprobe, httpprobe, cmdprobe, promprobe, grpcprobe, socketprobe, dnsprobe, logprobe, eventprobe, restartprobe, sqlprobe
+func RunProbes(chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) (err error) {
 
 	// get the probes details from the chaosengine
 	probes, err := getProbesFromChaosEngine(chaosDetails, clients)
@@ -40,6 +44,13 @@ func RunProbes(chaosDetails *types.ChaosDetails, clients clients.ClientSets, res
 				if err := execute(probe, chaosDetails, clients, resultDetails, phase); err != nil {
 					return err
 				}
+			case "onchaos", "eot":
+				// capturing the baseline of the prom probes, the values of the later phases are compared relative to it
+				if hasPromBaseline(probe, resultDetails) {
+					if err := execute(probe, chaosDetails, clients, resultDetails, phase); err != nil {
+						return err
+					}
+				}
 			}
 		}
 	//execute probes for the duringchaos phase
@@ -58,6 +69,13 @@ func RunProbes(chaosDetails *types.ChaosDetails, clients clients.ClientSets, res
 		var probeError []string
 		// call cancel function from chaosDetails context
 		chaosDetails.ProbeContext.CancelFunc()
+		// cleanup the source pods and resources of the probes, once all the probes are evaluated
+		// the experiment fails, if any of the resources can't be restored
+		defer func() {
+			if cleanupErr := CleanupProbes(resultDetails, chaosDetails, clients); cleanupErr != nil && err == nil {
+				err = cleanupErr
+			}
+		}()
 		for _, probe := range probes {
 			// evaluate continuous and onchaos probes
 			switch strings.ToLower(probe.Mode) {
@@ -83,6 +101,14 @@ func RunProbes(chaosDetails *types.ChaosDetails, clients clients.ClientSets, res
 	return nil
 }
 
+// CleanupProbes deletes the source pods of the cmd probes and restores the resources created or modified by the k8s probes
+// it is called at the end, failure or abort of the experiment, as the source pods and resources are kept across the phases
+// it returns the error, if any of the resources can't be restored
+func CleanupProbes(resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, clients clients.ClientSets) error {
+	deleteSourcePods(resultDetails, chaosDetails, clients)
+	return restoreK8sProbeResources(resultDetails, clients)
+}
+
 // setProbeVerdict mark the verdict of the probe in the chaosresult as passed
 // on the basis of phase(pre/post chaos)
 func setProbeVerdict(resultDetails *types.ResultDetails, probe v1alpha1.ProbeAttributes, verdict v1alpha1.ProbeVerdict, description, phase string) {
@@ -148,6 +174,33 @@ func getAndIncrementRunCount(resultDetails *types.ResultDetails, probeName strin
 	return 0
 }
 
+// probeObserver records the metrics and span of the probe evaluation
+type probeObserver struct {
+	probe     v1alpha1.ProbeAttributes
+	startTime time.Time
+	span      trace.Span
+}
+
+// observeProbe starts the observation of the probe evaluation
+// it is deferred by the trigger functions of the probes, e.g. defer observeProbe(probe).end(&err)
+func observeProbe(probe v1alpha1.ProbeAttributes) *probeObserver {
+	return &probeObserver{
+		probe:     probe,
+		startTime: time.Now(),
+		span: tracing.StartSpan("Probe "+probe.Name,
+			attribute.String("probe.name", probe.Name),
+			attribute.String("probe.type", probe.Type),
+			attribute.String("probe.mode", probe.Mode),
+		),
+	}
+}
+
+// end records the result and duration of the probe evaluation
+func (o *probeObserver) end(err *error) {
+	metrics.RecordProbe(o.probe.Name, o.probe.Type, o.probe.Mode, *err == nil, time.Since(o.startTime))
+	tracing.EndSpan(o.span, *err, string(cerrors.GetErrorType(*err)))
+}
+
 // getRunIDFromProbe return the run_id for the dedicated probe
 // which will used in the continuous cmd probe, run_id is used as suffix in the external pod name
 func getRunIDFromProbe(resultDetails *types.ResultDetails, probeName, probeType string) string {
@@ -245,6 +298,15 @@ func getProbeTimeouts(name string, probeDetails []*types.ProbeDetails) types.Pro
 	return types.ProbeTimeouts{}
 }
 
+// getProbeInputs returns the extended inputs of a probe given its name
+func getProbeInputs(name string, probeDetails []*types.ProbeDetails) types.ProbeInputs {
+	probe := getProbeByName(name, probeDetails)
+	if probe != nil {
+		return probe.Inputs
+	}
+	return types.ProbeInputs{}
+}
+
 func getDescription(err error) string {
 	rootCause := stacktrace.RootCause(err)
 	if error, ok := rootCause.(cerrors.Error); ok {
@@ -289,9 +351,12 @@ loop:
 // if command doesn't have template, it will return the same command
 func parseCommand(templatedCommand string, resultDetails *types.ResultDetails) (string, error) {
 
-	register := resultDetails.ProbeArtifacts
+	register := getTemplateData(resultDetails)
 
-	t := template.Must(template.New("t1").Parse(templatedCommand))
+	t, err := template.New("t1").Parse(templatedCommand)
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to parse the templated command, %s", err.Error())}
+	}
 
 	// store the parsed output in the buffer
 	var out bytes.Buffer
@@ -327,6 +392,10 @@ func stopChaosEngine(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 
 // execute contains steps to execute & evaluate probes in different modes at different phases
 func execute(probe v1alpha1.ProbeAttributes, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string) error {
+	// it validates the halt, which is supported only for the probes evaluated during chaos
+	if err = validateHaltProbe(probe, resultDetails); err != nil {
+		return stacktrace.Propagate(err, "probes failed")
+	}
 	switch strings.ToLower(probe.Type) {
 	case "k8sprobe":
 		// it contains steps to prepare the k8s probe
@@ -348,6 +417,41 @@ func execute(probe v1alpha1.ProbeAttributes, chaosDetails *types.ChaosDetails, c
 		if err = preparePromProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
 			return stacktrace.Propagate(err, "probes failed")
 		}
+	case "grpcprobe":
+		// it contains steps to prepare grpc probe
+		if err = prepareGRPCProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+			return stacktrace.Propagate(err, "probes failed")
+		}
+	case "socketprobe":
+		// it contains steps to prepare socket probe
+		if err = prepareSocketProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+			return stacktrace.Propagate(err, "probes failed")
+		}
+	case "dnsprobe":
+		// it contains steps to prepare dns probe
+		if err = prepareDNSProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+			return stacktrace.Propagate(err, "probes failed")
+		}
+	case "logprobe":
+		// it contains steps to prepare log probe
+		if err = prepareLogProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+			return stacktrace.Propagate(err, "probes failed")
+		}
+	case "eventprobe":
+		// it contains steps to prepare event probe
+		if err = prepareEventProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+			return stacktrace.Propagate(err, "probes failed")
+		}
+	case "restartprobe":
+		// it contains steps to prepare restart probe
+		if err = prepareRestartProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+			return stacktrace.Propagate(err, "probes failed")
+		}
+	case "sqlprobe":
+		// it contains steps to prepare sql probe
+		if err = prepareSQLProbe(probe, clients, chaosDetails, resultDetails, phase); err != nil {
+			return stacktrace.Propagate(err, "probes failed")
+		}
 	default:
 		return stacktrace.Propagate(err, "%v probe type not supported", probe.Type)
 	}
@@ -384,7 +488,11 @@ func getAttempts(attempt, retries int) int {
 
 func IsProbeFailed(reason string) bool {
 	if strings.Contains(reason, string(cerrors.FailureTypeK8sProbe)) || strings.Contains(reason, string(cerrors.FailureTypePromProbe)) ||
-		strings.Contains(reason, string(cerrors.FailureTypeCmdProbe)) || strings.Contains(reason, string(cerrors.FailureTypeHttpProbe)) {
+		strings.Contains(reason, string(cerrors.FailureTypeCmdProbe)) || strings.Contains(reason, string(cerrors.FailureTypeHttpProbe)) ||
+		strings.Contains(reason, string(cerrors.FailureTypeGRPCProbe)) || strings.Contains(reason, string(cerrors.FailureTypeSocketProbe)) ||
+		strings.Contains(reason, string(cerrors.FailureTypeDNSProbe)) || strings.Contains(reason, string(cerrors.FailureTypeLogProbe)) ||
+		strings.Contains(reason, string(cerrors.FailureTypeEventProbe)) || strings.Contains(reason, string(cerrors.FailureTypeRestartProbe)) ||
+		strings.Contains(reason, string(cerrors.FailureTypeSQLProbe)) {
 		return true
 	}
 	return false
@@ -0,0 +1,63 @@
+package probe
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestCleanupProbes(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/namespaces/default/configmaps/nginx":
+			_ = json.NewEncoder(w).Encode(getConfigMap("102", map[string]interface{}{"mode": "normal"}).Object)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/namespaces/litmus/pods/pod-delete-probe-abcde":
+			_ = json.NewEncoder(w).Encode(v1.Status{Status: v1.StatusSuccess})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/namespaces/litmus/pods":
+			_ = json.NewEncoder(w).Encode(corev1.PodList{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	clientSets := clients.ClientSets{
+		KubeClient:    kubernetes.NewForConfigOrDie(&rest.Config{Host: server.URL}),
+		DynamicClient: dynamic.NewForConfigOrDie(&rest.Config{Host: server.URL}),
+	}
+
+	// the prechaos k8s and cmd probes, which are not evaluated in the postchaos phase after the failure or abort
+	k8sProbe := &types.ProbeDetails{Name: "k8s-patch", Type: "k8sProbe", Mode: "SOT", Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictPassed}}
+	cmdProbe := &types.ProbeDetails{Name: "cmd-source", Type: "cmdProbe", Mode: "Edge", RunID: "abcde"}
+	resultDetails := &types.ResultDetails{ProbeDetails: []*types.ProbeDetails{k8sProbe, cmdProbe}, PassedProbeCount: 1}
+	trackK8sResource(resultDetails, k8sProbe.Name, configMapGVR, "default", "nginx", getConfigMap("100", map[string]interface{}{"mode": "normal"}), getConfigMap("101", map[string]interface{}{"mode": "chaos"}))
+	chaosDetails := &types.ChaosDetails{ExperimentName: "pod-delete", ChaosNamespace: "litmus", Timeout: 2, Delay: 1}
+
+	require.NoError(t, CleanupProbes(resultDetails, chaosDetails, clientSets))
+	assert.Equal(t, []string{
+		"DELETE /api/v1/namespaces/litmus/pods/pod-delete-probe-abcde",
+		"GET /api/v1/namespaces/litmus/pods",
+		"PATCH /api/v1/namespaces/default/configmaps/nginx",
+	}, requests)
+	assert.Empty(t, k8sProbe.K8sResources)
+	assert.Empty(t, cmdProbe.RunID)
+
+	// the probes are cleaned up only once, e.g. at the failure after the postchaos probes
+	requests = nil
+	require.NoError(t, CleanupProbes(resultDetails, chaosDetails, clientSets))
+	assert.Empty(t, requests)
+}
@@ -1,9 +1,11 @@
 package probe
 
 import (
-	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
-	"os/exec"
+	gomath "math"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	cmp "github.com/figwood/litmus-go/pkg/probe/comparator"
 	"github.com/figwood/litmus-go/pkg/types"
 	"github.com/figwood/litmus-go/pkg/utils/retry"
+	"github.com/prometheus/common/model"
 	"github.com/sirupsen/logrus"
 )
 
@@ -22,6 +25,13 @@ import (
 // which compares the metrics output exposed at the given endpoint
 func preparePromProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
 
+	// deriving the header values from the secrets
+	if inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).PromProbeInputs; inputs != nil {
+		if err := setHeaderValues(inputs.Headers, probe.Name, clients, chaosDetails.ChaosNamespace, cerrors.ErrorTypePromProbe); err != nil {
+			return err
+		}
+	}
+
 	switch strings.ToLower(phase) {
 	case "prechaos":
 		if err := preChaosPromProbe(probe, resultDetails, clients, chaosDetails); err != nil {
@@ -45,6 +55,11 @@ func preparePromProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets
 func preChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
 
+	// capturing the baseline value, the later values are compared relative to it
+	if hasPromBaseline(probe, resultDetails) {
+		return preChaosPromBaseline(probe, resultDetails, clients, chaosDetails)
+	}
+
 	switch strings.ToLower(probe.Mode) {
 	case "sot", "edge":
 
@@ -96,6 +111,56 @@ func preChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 	return nil
 }
 
+// hasPromBaseline checks whether the prom probe compares the values relative to the baseline
+// the baseline is captured in the prechaos phase for all the modes
+func hasPromBaseline(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) bool {
+	if !strings.EqualFold(probe.Type, "promProbe") {
+		return false
+	}
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).PromProbeInputs
+	return inputs != nil && inputs.Baseline != nil
+}
+
+// preChaosPromBaseline capture the baseline value of the prometheus probe for prechaos phase
+// for the SOT and Edge modes, the verdict of the prechaos phase is based upon the baseline capture
+func preChaosPromBaseline(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+
+	//DISPLAY THE PROMETHEUS PROBE INFO
+	log.InfoWithValues("[Probe]: The prometheus probe baseline information is as follows", logrus.Fields{
+		"Name":           probe.Name,
+		"Query":          probe.PromProbeInputs.Query,
+		"Endpoint":       probe.PromProbeInputs.Endpoint,
+		"Relation":       getProbeInputs(probe.Name, resultDetails.ProbeDetails).PromProbeInputs.Baseline.Relation,
+		"Run Properties": probe.RunProperties,
+		"Mode":           probe.Mode,
+		"Phase":          "PreChaos",
+	})
+
+	// waiting for initial delay, the other modes wait for it before their own execution
+	if probeTimeout.InitialDelay != 0 && (strings.ToLower(probe.Mode) == "sot" || strings.ToLower(probe.Mode) == "edge") {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+	}
+
+	// capturing the baseline value, the probe will fail in the later phases if the baseline isn't captured
+	if err = capturePromBaseline(probe, resultDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypePromProbe {
+		return err
+	}
+
+	switch strings.ToLower(probe.Mode) {
+	case "sot", "edge":
+		// failing the probe, if the baseline isn't captured after the retry & timeout combinations
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PreChaos"); err != nil {
+			return err
+		}
+	case "continuous":
+		// trigger the continuous prom probe
+		go triggerContinuousPromProbe(probe, clients, resultDetails, chaosDetails)
+	}
+	return nil
+}
+
 // postChaosPromProbe trigger the prometheus probe for postchaos phase
 func postChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
@@ -170,59 +235,140 @@ func onChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 	return nil
 }
 
-// triggerPromProbe trigger the prometheus probe inside the external pod
-func triggerPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) error {
+// triggerPromProbe trigger the prometheus probe
+func triggerPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) (err error) {
+	defer observeProbe(probe).end(&err)
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).PromProbeInputs
+
+	var baseline *types.PromBaseline
+	if inputs != nil {
+		baseline = inputs.Baseline
+	}
+
+	return runPromProbe(probe, resultDetails, func(value string, rc int) (string, error) {
+		if baseline == nil {
+			// comparing the metrics output with the expected criteria
+			if err := comparePromValue(probe, value, rc); err != nil {
+				log.Errorf("The %v prom probe has been Failed, err: %v", probe.Name, err)
+				return "", err
+			}
+			return fmt.Sprintf("Obtained the specified prometheus metrics. Actual value: %s. Expected value: %s", value, probe.PromProbeInputs.Comparator.Value), nil
+		}
+
+		baselineValue := getProbeByName(probe.Name, resultDetails.ProbeDetails).Baseline
+		if baselineValue == "" {
+			return "", cerrors.Error{ErrorCode: cerrors.FailureTypePromProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "baseline value is not captured in the prechaos phase"}
+		}
+		relative, err := getRelativeValue(value, baselineValue, baseline.Relation, probe.Name)
+		if err != nil {
+			return "", err
+		}
+
+		// comparing the value relative to the baseline with the expected criteria
+		if err := comparePromValue(probe, relative, rc); err != nil {
+			log.Errorf("The %v prom probe has been Failed, err: %v", probe.Name, err)
+			if cerrors.GetErrorType(err) != cerrors.FailureTypePromProbe {
+				return "", err
+			}
+			return "", cerrors.Error{ErrorCode: cerrors.FailureTypePromProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("Baseline value: %s. Observed value: %s. %s", baselineValue, value, getDescription(err))}
+		}
+		return fmt.Sprintf("Obtained the specified prometheus metrics. Baseline value: %s. Observed value: %s. Actual %s: %s. Expected %s: %s", baselineValue, value, baseline.Relation, relative, baseline.Relation, probe.PromProbeInputs.Comparator.Value), nil
+	})
+}
+
+// comparePromValue compares the value with the expected value based on the comparator type
+// it compares the values as float, if comparator type is not provided
+func comparePromValue(probe v1alpha1.ProbeAttributes, value string, rc int) error {
+	comparatorType := probe.PromProbeInputs.Comparator.Type
+	if comparatorType == "" {
+		comparatorType = "float"
+	}
+	if !cmp.IsSupportedType(comparatorType) {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("comparator type '%s' not supported in the prom probe", comparatorType)}
+	}
+	return cmp.RunCount(rc).
+		FirstValue(value).
+		SecondValue(probe.PromProbeInputs.Comparator.Value).
+		Criteria(probe.PromProbeInputs.Comparator.Criteria).
+		ProbeName(probe.Name).
+		ProbeVerbosity(probe.RunProperties.Verbosity).
+		Compare(comparatorType, cerrors.FailureTypePromProbe)
+}
+
+// capturePromBaseline runs the prometheus query and stores the value as baseline
+func capturePromBaseline(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) error {
+	return runPromProbe(probe, resultDetails, func(value string, rc int) (string, error) {
+		getProbeByName(probe.Name, resultDetails.ProbeDetails).Baseline = value
+		log.Infof("[Probe]: The baseline value of %v prom probe is %v", probe.Name, value)
+		return fmt.Sprintf("Captured the baseline value of the prometheus metrics. Baseline value: %s", value), nil
+	})
+}
+
+// runPromProbe runs the prometheus query and validate the extracted value using the given function
+func runPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, validate func(value string, rc int) (string, error)) error {
 	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).PromProbeInputs
+
+	query, err := getPromQuery(probe.PromProbeInputs, probe.Name)
+	if err != nil {
+		return err
+	}
+
+	client, err := getPromAPI(probe.PromProbeInputs.Endpoint, inputs, probe.Name)
+	if err != nil {
+		return err
+	}
+
+	var queryRange *types.PromRange
+	if inputs != nil {
+		queryRange = inputs.Range
+	}
 
 	var description string
-	// running the prom probe command and matching the output
+	// running the prom query and matching the output
 	// it will retry for some retry count, in each iteration of try it contains following things
 	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
-	// for a timeout, it will run the command, if it fails wait for the interval and again execute the command until timeout expires
+	// for a timeout, it will run the query, if it fails wait for the interval and again execute the query until timeout expires
 	if err := retry.Times(uint(getAttempts(probe.RunProperties.Attempt, probe.RunProperties.Retry))).
 		Timeout(probeTimeout.ProbeTimeout).
 		Wait(probeTimeout.Interval).
+		Context(types.AbortContext()).
 		TryWithTimeout(func(attempt uint) error {
-			var command string
-			// It will use query or queryPath to get the prometheus metrics
-			// if both are provided, it will use query
-			if probe.PromProbeInputs.Query != "" {
-				command = "promql --host " + probe.PromProbeInputs.Endpoint + " \"" + probe.PromProbeInputs.Query + "\"" + " --output csv"
-			} else if probe.PromProbeInputs.QueryPath != "" {
-				command = "promql --host " + probe.PromProbeInputs.Endpoint + " \"$(cat " + probe.PromProbeInputs.QueryPath + ")\"" + " --output csv"
-			} else {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "[Probe]: Any one of query or queryPath is required"}
+			ctx, cancel := context.WithCancel(context.Background())
+			if probeTimeout.ProbeTimeout != 0 {
+				ctx, cancel = context.WithTimeout(context.Background(), probeTimeout.ProbeTimeout)
 			}
+			defer cancel()
 
-			var out, errOut bytes.Buffer
-			// run the inline command probe
-			cmd := exec.Command("/bin/sh", "-c", command)
-			cmd.Stdout = &out
-			cmd.Stderr = &errOut
-			if err := cmd.Run(); err != nil {
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to run command, error: %s", errOut.String())}
+			result, warnings, err := runPromQuery(ctx, client, query, queryRange, probe.Name)
+			if err != nil {
+				if cerrors.IsUserFriendly(err) {
+					return err
+				}
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to run the query, err: %v", err)}
+			}
+			if len(warnings) != 0 {
+				log.Warnf("The %v prom probe query returned warnings: %v", probe.Name, strings.Join(warnings, ", "))
 			}
 
 			// extract the values from the metrics
-			value, err := extractValueFromMetrics(strings.TrimSpace(out.String()), probe.Name)
+			value, err := extractValueFromMetrics(result, queryRange, probe.Name)
 			if err != nil {
 				return err
 			}
 
 			rc := getAndIncrementRunCount(resultDetails, probe.Name)
-			// comparing the metrics output with the expected criteria
-			if err = cmp.RunCount(rc).
-				FirstValue(value).
-				SecondValue(probe.PromProbeInputs.Comparator.Value).
-				Criteria(probe.PromProbeInputs.Comparator.Criteria).
-				ProbeName(probe.Name).
-				ProbeVerbosity(probe.RunProperties.Verbosity).
-				CompareFloat(cerrors.FailureTypePromProbe); err != nil {
-				log.Errorf("The %v prom probe has been Failed, err: %v", probe.Name, err)
+			if description, err = validate(value, rc); err != nil {
 				return err
 			}
-			description = fmt.Sprintf("Obtained the specified prometheus metrics. Actual value: %s. Expected value: %s", value, probe.PromProbeInputs.Comparator.Value)
-			return nil
+
+			// register the extracted value along with the named outputs, which can be referenced by the later probes
+			// the jsonpath of the named outputs is evaluated over the query result
+			document, err := json.Marshal(result)
+			if err != nil {
+				return cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("unable to encode the query result, err: %v", err)}
+			}
+			return registerProbeOutput(probe, value, document, resultDetails, cerrors.FailureTypePromProbe)
 		}); err != nil {
 		return checkProbeTimeoutError(probe.Name, cerrors.FailureTypePromProbe, err)
 	}
@@ -230,6 +376,43 @@ func triggerPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 	return nil
 }
 
+// getRelativeValue returns the observed value relative to the baseline value
+func getRelativeValue(observed, baseline, relation, probeName string) (string, error) {
+	observedValue, err := strconv.ParseFloat(observed, 64)
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to parse the observed value, err: %v", err)}
+	}
+	baselineValue, err := strconv.ParseFloat(baseline, 64)
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to parse the baseline value, err: %v", err)}
+	}
+
+	var relative float64
+	switch strings.ToLower(relation) {
+	case "ratio":
+		relative = divide(observedValue, baselineValue)
+	case "delta":
+		relative = observedValue - baselineValue
+	case "percent":
+		relative = divide(observedValue-baselineValue, baselineValue) * 100
+	default:
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("baseline relation '%s' not supported in the prom probe", relation)}
+	}
+	return strconv.FormatFloat(relative, 'f', -1, 64), nil
+}
+
+// divide returns the quotient, it returns zero if both the values are zero
+// and infinity with the sign of the dividend if only the divisor is zero
+func divide(dividend, divisor float64) float64 {
+	if divisor == 0 {
+		if dividend == 0 {
+			return 0
+		}
+		return gomath.Inf(int(gomath.Copysign(1, dividend)))
+	}
+	return dividend / divisor
+}
+
 // triggerContinuousPromProbe trigger the continuous prometheus probe
 func triggerContinuousPromProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
 	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
@@ -274,6 +457,10 @@ loop:
 			time.Sleep(probeTimeout.ProbePollingInterval)
 		}
 	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
 	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
 	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
 	// and failed the experiment in the end
@@ -344,6 +531,10 @@ loop:
 			}
 		}
 	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
 	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
 	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
 	// and failed the experiment in the end
@@ -354,37 +545,74 @@ loop:
 	}
 }
 
-// extractValueFromMetrics extract the value field from the prometheus metrix
-func extractValueFromMetrics(metrics, probeName string) (string, error) {
-
-	// spliting the metrics based on newline as metrics may have multiple entries
-	rows := strings.Split(metrics, "\n")
-
-	// output should contains exact one metrics entry along with header
-	// erroring out the cases where it contains more or less entries
-	if len(rows) > 2 {
-		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "metrics entries can't be more than two"}
-	} else if len(rows) < 2 {
-		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "metrics doesn't contains required values"}
-	}
-
-	// deriving the index for the value column from the headers
-	headerColumn := strings.Split(rows[0], ",")
-	indexForValueColumn := -1
-	for index := range headerColumn {
-		if strings.ToLower(headerColumn[index]) == "value" {
-			indexForValueColumn = index
-			break
+// extractValueFromMetrics extract the value from the result of the prometheus query
+// the result should contain exactly one series, the samples of the range query are reduced based on the aggregation
+func extractValueFromMetrics(result model.Value, queryRange *types.PromRange, probeName string) (string, error) {
+
+	var value model.SampleValue
+	switch metrics := result.(type) {
+	case *model.Scalar:
+		value = metrics.Value
+	case model.Vector:
+		// output should contains exact one metrics entry
+		// erroring out the cases where it contains more or less entries
+		if len(metrics) > 1 {
+			return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("metrics entries can't be more than one, found %d entries", len(metrics))}
+		} else if len(metrics) == 0 {
+			return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "metrics doesn't contains required values"}
 		}
+		value = metrics[0].Value
+	case model.Matrix:
+		if len(metrics) > 1 {
+			return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("metrics series can't be more than one, found %d series", len(metrics))}
+		} else if len(metrics) == 0 || len(metrics[0].Values) == 0 {
+			return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "metrics doesn't contains required values"}
+		}
+		aggregation := ""
+		if queryRange != nil {
+			aggregation = queryRange.Aggregation
+		}
+		var err error
+		if value, err = aggregateSamples(metrics[0].Values, aggregation, probeName); err != nil {
+			return "", err
+		}
+	default:
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("metrics of type '%s' not supported in the prom probe", result.Type())}
 	}
-	if indexForValueColumn == -1 {
-		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "metrics entries doesn't contains value column"}
-	}
+	return strconv.FormatFloat(float64(value), 'f', -1, 64), nil
+}
 
-	// splitting the metrics entries which are available as comma separated
-	values := strings.Split(rows[1], ",")
-	if values[indexForValueColumn] == "" {
-		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "error while parsing value from derived matrics"}
+// aggregateSamples reduce the samples of the range query to a single value
+func aggregateSamples(samples []model.SamplePair, aggregation, probeName string) (model.SampleValue, error) {
+	switch strings.ToLower(aggregation) {
+	case "last", "":
+		return samples[len(samples)-1].Value, nil
+	case "min":
+		value := samples[0].Value
+		for _, sample := range samples[1:] {
+			if sample.Value < value {
+				value = sample.Value
+			}
+		}
+		return value, nil
+	case "max":
+		value := samples[0].Value
+		for _, sample := range samples[1:] {
+			if sample.Value > value {
+				value = sample.Value
+			}
+		}
+		return value, nil
+	case "sum", "avg":
+		var sum model.SampleValue
+		for _, sample := range samples {
+			sum += sample.Value
+		}
+		if strings.ToLower(aggregation) == "avg" {
+			return sum / model.SampleValue(len(samples)), nil
+		}
+		return sum, nil
+	default:
+		return 0, cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("aggregation '%s' not supported in the prom probe", aggregation)}
 	}
-	return values[indexForValueColumn], nil
 }
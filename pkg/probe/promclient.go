@@ -0,0 +1,123 @@
+package probe
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// promRoundTripper adds the auth and custom headers to each prometheus query
+type promRoundTripper struct {
+	headers http.Header
+	next    http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (rt promRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range rt.headers {
+		req.Header[name] = values
+	}
+	return rt.next.RoundTrip(req)
+}
+
+// getPromAPI returns the client of the prometheus http api for the given endpoint
+func getPromAPI(endpoint string, inputs *types.PromProbeInputs, probeName string) (promv1.API, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	headers := http.Header{}
+
+	if inputs != nil {
+		if inputs.TLS != nil {
+			tlsConfig, err := getTLSConfig(inputs.TLS, probeName, cerrors.ErrorTypePromProbe)
+			if err != nil {
+				return nil, err
+			}
+			transport.TLSClientConfig = tlsConfig
+		}
+		if err := setPromAuthHeader(headers, inputs.Auth, probeName); err != nil {
+			return nil, err
+		}
+		for _, header := range inputs.Headers {
+			headers.Set(header.Name, header.Value)
+		}
+	}
+
+	client, err := api.NewClient(api.Config{
+		Address:      endpoint,
+		RoundTripper: promRoundTripper{headers: headers, next: transport},
+	})
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to create the prometheus client for %s endpoint, err: %v", endpoint, err)}
+	}
+	return promv1.NewAPI(client), nil
+}
+
+// setPromAuthHeader sets the authorization header from the bearer token or basic auth credentials
+func setPromAuthHeader(headers http.Header, auth *types.PromAuth, probeName string) error {
+	switch {
+	case auth == nil:
+		return nil
+	case auth.BearerTokenPath != "":
+		token, err := os.ReadFile(auth.BearerTokenPath)
+		if err != nil {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to read the bearer token, err: %v", err)}
+		}
+		headers.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	case auth.Username != "":
+		password, err := os.ReadFile(auth.PasswordPath)
+		if err != nil {
+			return cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to read the password, err: %v", err)}
+		}
+		credentials := auth.Username + ":" + strings.TrimSpace(string(password))
+		headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+	return nil
+}
+
+// getPromQuery returns the prometheus query
+// It will use query or queryPath to get the prometheus metrics
+// if both are provided, it will use query
+func getPromQuery(inputs *v1alpha1.PromProbeInputs, probeName string) (string, error) {
+	if inputs.Query != "" {
+		return inputs.Query, nil
+	}
+	if inputs.QueryPath == "" {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "[Probe]: Any one of query or queryPath is required"}
+	}
+	query, err := os.ReadFile(inputs.QueryPath)
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to read the query, err: %v", err)}
+	}
+	return strings.TrimSpace(string(query)), nil
+}
+
+// runPromQuery runs the instant query or the range query, if range is provided
+func runPromQuery(ctx context.Context, client promv1.API, query string, queryRange *types.PromRange, probeName string) (model.Value, promv1.Warnings, error) {
+	now := time.Now()
+	if queryRange == nil {
+		return client.Query(ctx, query, now)
+	}
+
+	window, err := time.ParseDuration(queryRange.Window)
+	if err != nil {
+		return nil, nil, cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to parse the range window, err: %v", err)}
+	}
+	step := 15 * time.Second
+	if queryRange.Step != "" {
+		if step, err = time.ParseDuration(queryRange.Step); err != nil {
+			return nil, nil, cerrors.Error{ErrorCode: cerrors.ErrorTypePromProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to parse the range step, err: %v", err)}
+		}
+	}
+	return client.QueryRange(ctx, query, promv1.Range{Start: now.Add(-window), End: now, Step: step})
+}
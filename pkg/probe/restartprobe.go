@@ -0,0 +1,319 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/figwood/litmus-go/pkg/workloads"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// prepareRestartProbe contains the steps to prepare the restart probe
+// restart probe can be used to add the probe which will detect the restarted or OOMKilled containers, other than the chaos targets
+func prepareRestartProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+
+	// the containers are compared against the snapshot captured in the prechaos phase, so the probe is supported only in edge and continuous modes
+	switch strings.ToLower(probe.Mode) {
+	case "edge", "continuous":
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeRestartProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("mode '%s' not supported in the restart probe, use edge mode to verify the containers at the end of the chaos", probe.Mode)}
+	}
+
+	switch strings.ToLower(phase) {
+	case "prechaos":
+		if err := preChaosRestartProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "postchaos":
+		if err := postChaosRestartProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeRestartProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("phase '%s' not supported in the restart probe", phase)}
+	}
+	return nil
+}
+
+// getRestartProbeInputs returns the inputs of the restart probe
+// the inputs are optional, it checks all the pods of the chaos target namespaces by default
+func getRestartProbeInputs(probeName string, probeDetails []*types.ProbeDetails) *types.RestartProbeInputs {
+	if inputs := getProbeInputs(probeName, probeDetails).RestartProbeInputs; inputs != nil {
+		return inputs
+	}
+	return &types.RestartProbeInputs{}
+}
+
+// listRestartProbePods returns the pods of all the namespaces of the restart probe
+func listRestartProbePods(ctx context.Context, probeName string, inputs *types.RestartProbeInputs, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]corev1.Pod, error) {
+	namespaces := getTargetNamespaces(inputs.Namespaces, chaosDetails)
+	if len(namespaces) == 0 {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeRestartProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "[Probe]: Any one of namespaces or chaos targets is required to check the containers"}
+	}
+
+	var pods []corev1.Pod
+	for _, namespace := range namespaces {
+		podList, err := clients.KubeClient.CoreV1().Pods(namespace).List(ctx, v1.ListOptions{LabelSelector: inputs.LabelSelector})
+		if err != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeRestartProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to list the pods in %s namespace, err: %v", namespace, err)}
+		}
+		pods = append(pods, podList.Items...)
+	}
+	return pods, nil
+}
+
+// getContainerSnapshots returns the restart count and termination state of all the containers of the pods
+func getContainerSnapshots(pods []corev1.Pod) map[string]types.ContainerSnapshot {
+	snapshots := map[string]types.ContainerSnapshot{}
+	for _, pod := range pods {
+		for _, container := range pod.Status.ContainerStatuses {
+			snapshots[string(pod.UID)+"/"+container.Name] = types.ContainerSnapshot{
+				RestartCount: container.RestartCount,
+				Terminated:   container.State.Terminated != nil,
+			}
+		}
+	}
+	return snapshots
+}
+
+// isChaosTargetPod checks whether the pod is expected to be disrupted by the experiment
+// it includes the target pods, the pods of the target workloads, the pods scheduled on the target nodes and the helper pods of the experiment
+// the targets are recorded without the namespace, so the target pods and workloads are matched only inside the target namespaces
+func isChaosTargetPod(pod corev1.Pod, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (bool, error) {
+	if hasHelperLabels(&pod, chaosDetails) {
+		return true, nil
+	}
+	inTargetNamespace := containsFold(getTargetNamespaces(nil, chaosDetails), pod.Namespace)
+
+	var ownerKind, ownerName string
+	ownerResolved := false
+	for _, target := range chaosDetails.Targets {
+		kind := strings.ToLower(target.Kind)
+		switch kind {
+		case "node":
+			if target.Name == pod.Spec.NodeName {
+				return true, nil
+			}
+		case "pod":
+			if inTargetNamespace && target.Name == pod.Name {
+				return true, nil
+			}
+		case "deployment", "statefulset", "daemonset", "deploymentconfig", "rollout":
+			if !inTargetNamespace {
+				continue
+			}
+			// the pods of the workloads are resolved via the owner references, e.g. pod -> replicaset -> deployment
+			if !ownerResolved {
+				var err error
+				if ownerKind, ownerName, err = workloads.GetPodOwnerTypeAndName(&pod, clients.DynamicClient); err != nil {
+					return false, cerrors.Error{ErrorCode: cerrors.ErrorTypeRestartProbe, Target: fmt.Sprintf("{podName: %s, namespace: %s}", pod.Name, pod.Namespace), Reason: fmt.Sprintf("unable to get the owner of the pod, err: %v", err)}
+				}
+				ownerResolved = true
+			}
+			if kind == ownerKind && target.Name == ownerName {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// getRestartedContainers returns the containers which are restarted or OOMKilled after the snapshot, other than the chaos targets
+// the containers of the pods created after the snapshot are compared against the zero restart count
+func getRestartedContainers(pods []corev1.Pod, snapshots map[string]types.ContainerSnapshot, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]string, error) {
+	var restarts []string
+	for _, pod := range pods {
+		var podRestarts []string
+		for _, container := range pod.Status.ContainerStatuses {
+			snapshot := snapshots[string(pod.UID)+"/"+container.Name]
+			name := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, container.Name)
+
+			switch {
+			case container.RestartCount > snapshot.RestartCount:
+				restart := fmt.Sprintf("%s: restarted %d times", name, container.RestartCount-snapshot.RestartCount)
+				if terminated := container.LastTerminationState.Terminated; terminated != nil {
+					restart += fmt.Sprintf(", last terminated with %s (exit code %d)", terminated.Reason, terminated.ExitCode)
+				}
+				podRestarts = append(podRestarts, restart)
+			case !snapshot.Terminated && container.State.Terminated != nil && container.State.Terminated.Reason == "OOMKilled":
+				podRestarts = append(podRestarts, fmt.Sprintf("%s: terminated with OOMKilled (exit code %d)", name, container.State.Terminated.ExitCode))
+			}
+		}
+		if len(podRestarts) == 0 {
+			continue
+		}
+		// the owners of the pods are looked up only for the restarted containers
+		isTarget, err := isChaosTargetPod(pod, clients, chaosDetails)
+		if err != nil {
+			return nil, err
+		}
+		if !isTarget {
+			restarts = append(restarts, podRestarts...)
+		}
+	}
+	sort.Strings(restarts)
+	return restarts, nil
+}
+
+// checkContainerRestarts verify that no container other than the chaos targets is restarted or OOMKilled after the snapshot
+// it records the restarted containers inside the probe details
+func checkContainerRestarts(ctx context.Context, probe v1alpha1.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+	inputs := getRestartProbeInputs(probe.Name, resultDetails.ProbeDetails)
+	probeDetails := getProbeByName(probe.Name, resultDetails.ProbeDetails)
+	if probeDetails == nil || probeDetails.Containers == nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeRestartProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "the snapshot of the containers is not captured in the prechaos phase"}
+	}
+
+	pods, err := listRestartProbePods(ctx, probe.Name, inputs, clients, chaosDetails)
+	if err != nil {
+		return err
+	}
+	restarts, err := getRestartedContainers(pods, probeDetails.Containers, clients, chaosDetails)
+	if err != nil {
+		return err
+	}
+
+	maxArtifacts := inputs.MaxArtifacts
+	if maxArtifacts == 0 {
+		maxArtifacts = 10
+	}
+	probeDetails.Artifacts = restarts
+	if len(restarts) > maxArtifacts {
+		probeDetails.Artifacts = restarts[:maxArtifacts]
+	}
+
+	if len(restarts) != 0 {
+		for _, restart := range restarts {
+			log.Errorf("[Probe]: The %v restart probe found the restarted container, %v", probe.Name, restart)
+		}
+		return cerrors.Error{ErrorCode: cerrors.FailureTypeRestartProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("%d containers other than the chaos targets have been restarted or OOMKilled during chaos", len(restarts))}
+	}
+	probeDetails.Status.Description = "No container other than the chaos targets has been restarted or OOMKilled during chaos"
+	return nil
+}
+
+// triggerContinuousRestartProbe checks the containers repeatedly for the entire duration of chaos
+// it stops at the first restarted container or checks the containers one last time, once the chaos is completed
+func triggerContinuousRestartProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+	}
+
+	var err error
+	var isExperimentFailed bool
+loop:
+	for {
+		select {
+		case <-chaosDetails.ProbeContext.Ctx.Done():
+			log.Infof("Stopping %s continuous Probe", probe.Name)
+			err = checkContainerRestarts(context.Background(), probe, clients, chaosresult, chaosDetails)
+			break loop
+		default:
+			if err = checkContainerRestarts(chaosDetails.ProbeContext.Ctx, probe, clients, chaosresult, chaosDetails); err != nil && chaosDetails.ProbeContext.Ctx.Err() == nil {
+				isExperimentFailed = true
+				break loop
+			}
+			time.Sleep(probeTimeout.ProbePollingInterval)
+		}
+	}
+
+	// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+	for index := range chaosresult.ProbeDetails {
+		if chaosresult.ProbeDetails[index].Name == probe.Name {
+			if err != nil {
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+				chaosresult.ProbeDetails[index].Status.Description = getDescription(err)
+				log.Errorf("The %v restart probe has been Failed, err: %v", probe.Name, err)
+			}
+			chaosresult.ProbeDetails[index].HasProbeCompleted = true
+		}
+	}
+
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
+}
+
+// preChaosRestartProbe captures the snapshot of the containers for prechaos phase
+func preChaosRestartProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	inputs := getRestartProbeInputs(probe.Name, resultDetails.ProbeDetails)
+
+	//DISPLAY THE RESTART PROBE INFO
+	log.InfoWithValues("[Probe]: The restart probe information is as follows", logrus.Fields{
+		"Name":           probe.Name,
+		"Namespaces":     getTargetNamespaces(inputs.Namespaces, chaosDetails),
+		"Label Selector": inputs.LabelSelector,
+		"Run Properties": probe.RunProperties,
+		"Mode":           probe.Mode,
+		"Phase":          "PreChaos",
+	})
+
+	pods, err := listRestartProbePods(context.Background(), probe.Name, inputs, clients, chaosDetails)
+	if err != nil {
+		return err
+	}
+	if probeDetails := getProbeByName(probe.Name, resultDetails.ProbeDetails); probeDetails != nil {
+		probeDetails.Containers = getContainerSnapshots(pods)
+		log.Infof("[Probe]: The %v restart probe captured the snapshot of %d containers", probe.Name, len(probeDetails.Containers))
+	}
+
+	switch strings.ToLower(probe.Mode) {
+	case "edge":
+		// the containers are verified only at the end of the chaos, so the prechaos instance passes once the snapshot is captured
+		if err := markedVerdictInEnd(nil, resultDetails, probe, "PreChaos"); err != nil {
+			return err
+		}
+	case "continuous":
+		go triggerContinuousRestartProbe(probe, clients, resultDetails, chaosDetails)
+	}
+	return nil
+}
+
+// postChaosRestartProbe verify the containers for postchaos phase
+func postChaosRestartProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	switch strings.ToLower(probe.Mode) {
+	case "edge":
+
+		//DISPLAY THE RESTART PROBE INFO
+		log.InfoWithValues("[Probe]: The restart probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PostChaos",
+		})
+		err := checkContainerRestarts(context.Background(), probe, clients, resultDetails, chaosDetails)
+		if err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeRestartProbe {
+			return err
+		}
+		// failing the probe, if any container other than the chaos targets is restarted
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	case "continuous":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		err := checkForErrorInContinuousProbe(resultDetails, probe.Name, chaosDetails.Delay, chaosDetails.Timeout)
+		if err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeRestartProbe && cerrors.GetErrorType(err) != cerrors.FailureTypeProbeTimeout {
+			return err
+		}
+		// failing the probe, if any container other than the chaos targets is restarted
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package probe
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// getRestartedPod returns the pod with the restarted container, owned by the given owner
+func getRestartedPod(name, namespace, nodeName string, labels map[string]string, owner ...v1.OwnerReference) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: namespace, UID: clientTypes.UID(namespace + "-" + name), Labels: labels, OwnerReferences: owner},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:         "app",
+			RestartCount: 1,
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Reason:   "Error",
+				ExitCode: 137,
+			}},
+		}}},
+	}
+}
+
+func TestGetRestartedContainers(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/apis/apps/v1/namespaces/default/replicasets/nginx-7d8f9c", "/apis/apps/v1/namespaces/other/replicasets/nginx-7d8f9c":
+			replicaSet := unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "apps/v1", "kind": "ReplicaSet"}}
+			replicaSet.SetName("nginx-7d8f9c")
+			replicaSet.SetOwnerReferences([]v1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "nginx"}})
+			_ = json.NewEncoder(w).Encode(replicaSet.Object)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(v1.Status{Status: v1.StatusFailure, Code: http.StatusNotFound, Reason: v1.StatusReasonNotFound})
+		}
+	}))
+	defer server.Close()
+	clientSets := clients.ClientSets{DynamicClient: dynamic.NewForConfigOrDie(&rest.Config{Host: server.URL})}
+
+	replicaSetOwner := v1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "nginx-7d8f9c"}
+	statefulSetOwner := v1.OwnerReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "mysql"}
+	nginxLabels := map[string]string{"app": "nginx", "pod-template-hash": "7d8f9c"}
+
+	tests := []struct {
+		name         string
+		targets      []v1alpha1.TargetDetails
+		pods         []corev1.Pod
+		want         []string
+		wantRequests []string
+		wantErr      bool
+	}{
+		{
+			name:    "pod target",
+			targets: []v1alpha1.TargetDetails{{Name: "nginx-7d8f9c-abcde", Kind: "pod"}},
+			pods:    []corev1.Pod{getRestartedPod("nginx-7d8f9c-abcde", "default", "worker-1", nil)},
+		},
+		{
+			name:    "pod target with the same name in other namespace",
+			targets: []v1alpha1.TargetDetails{{Name: "nginx-7d8f9c-abcde", Kind: "pod"}},
+			pods:    []corev1.Pod{getRestartedPod("nginx-7d8f9c-abcde", "other", "worker-1", nil)},
+			want:    []string{"other/nginx-7d8f9c-abcde/app: restarted 1 times, last terminated with Error (exit code 137)"},
+		},
+		{
+			name:         "deployment target",
+			targets:      []v1alpha1.TargetDetails{{Name: "nginx", Kind: "deployment"}},
+			pods:         []corev1.Pod{getRestartedPod("nginx-7d8f9c-abcde", "default", "worker-1", nginxLabels, replicaSetOwner)},
+			wantRequests: []string{"/apis/apps/v1/namespaces/default/replicasets/nginx-7d8f9c"},
+		},
+		{
+			name:    "deployment target with the same name in other namespace",
+			targets: []v1alpha1.TargetDetails{{Name: "nginx", Kind: "deployment"}},
+			pods:    []corev1.Pod{getRestartedPod("nginx-7d8f9c-abcde", "other", "worker-1", nginxLabels, replicaSetOwner)},
+			want:    []string{"other/nginx-7d8f9c-abcde/app: restarted 1 times, last terminated with Error (exit code 137)"},
+		},
+		{
+			name:         "pod of other deployment",
+			targets:      []v1alpha1.TargetDetails{{Name: "httpd", Kind: "deployment"}},
+			pods:         []corev1.Pod{getRestartedPod("nginx-7d8f9c-abcde", "default", "worker-1", nginxLabels, replicaSetOwner)},
+			want:         []string{"default/nginx-7d8f9c-abcde/app: restarted 1 times, last terminated with Error (exit code 137)"},
+			wantRequests: []string{"/apis/apps/v1/namespaces/default/replicasets/nginx-7d8f9c"},
+		},
+		{
+			name:    "statefulset target",
+			targets: []v1alpha1.TargetDetails{{Name: "mysql", Kind: "statefulset"}},
+			pods: []corev1.Pod{
+				getRestartedPod("mysql-0", "default", "worker-1", nil, statefulSetOwner),
+				getRestartedPod("mysql-backup-0", "default", "worker-1", nil, v1.OwnerReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "mysql-backup"}),
+			},
+			want: []string{"default/mysql-backup-0/app: restarted 1 times, last terminated with Error (exit code 137)"},
+		},
+		{
+			name:    "node target",
+			targets: []v1alpha1.TargetDetails{{Name: "worker-1", Kind: "node"}},
+			pods: []corev1.Pod{
+				getRestartedPod("nginx-7d8f9c-abcde", "other", "worker-1", nil),
+				getRestartedPod("nginx-7d8f9c-fghij", "other", "worker-2", nil),
+			},
+			want: []string{"other/nginx-7d8f9c-fghij/app: restarted 1 times, last terminated with Error (exit code 137)"},
+		},
+		{
+			name: "helper pod",
+			pods: []corev1.Pod{getRestartedPod("pod-cpu-hog-helper-hxwkt", "litmus", "worker-1", map[string]string{"app": "pod-cpu-hog-helper-abcde"})},
+		},
+		{
+			name:    "cloud target",
+			targets: []v1alpha1.TargetDetails{{Name: "i-0a1b2c3d", Kind: "EC2"}},
+			pods:    []corev1.Pod{getRestartedPod("nginx-7d8f9c-abcde", "default", "worker-1", nginxLabels, replicaSetOwner)},
+			want:    []string{"default/nginx-7d8f9c-abcde/app: restarted 1 times, last terminated with Error (exit code 137)"},
+		},
+		{
+			name:         "owner not found",
+			targets:      []v1alpha1.TargetDetails{{Name: "nginx", Kind: "deployment"}},
+			pods:         []corev1.Pod{getRestartedPod("nginx-5c6d7e-abcde", "default", "worker-1", map[string]string{"pod-template-hash": "5c6d7e"}, v1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "nginx-5c6d7e"})},
+			wantRequests: []string{"/apis/apps/v1/namespaces/default/replicasets/nginx-5c6d7e"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			chaosDetails := &types.ChaosDetails{
+				ExperimentName: "pod-cpu-hog",
+				ChaosNamespace: "litmus",
+				AppDetail:      []types.AppDetails{{Namespace: "default", Kind: "deployment", Labels: []string{"app=nginx"}}},
+				Targets:        tt.targets,
+			}
+			restarts, err := getRestartedContainers(tt.pods, map[string]types.ContainerSnapshot{}, clientSets, chaosDetails)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, cerrors.ErrorTypeRestartProbe, cerrors.GetErrorType(err))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, restarts)
+			}
+			assert.Equal(t, tt.wantRequests, requests)
+		})
+	}
+}
+
+func TestGetRestartedContainersWithoutRestarts(t *testing.T) {
+	pod := getRestartedPod("nginx-7d8f9c-abcde", "default", "worker-1", map[string]string{"pod-template-hash": "7d8f9c"}, v1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "nginx-7d8f9c"})
+	oomKilled := getRestartedPod("httpd-0", "default", "worker-1", nil)
+	oomKilled.Status.ContainerStatuses[0].RestartCount = 0
+	oomKilled.Status.ContainerStatuses[0].State.Terminated = &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}
+	snapshots := getContainerSnapshots([]corev1.Pod{pod})
+
+	// the owners aren't looked up for the pods without the restarted containers, so the dynamic client isn't required
+	chaosDetails := &types.ChaosDetails{Targets: []v1alpha1.TargetDetails{{Name: "nginx", Kind: "deployment"}}}
+	restarts, err := getRestartedContainers([]corev1.Pod{pod, oomKilled}, snapshots, clients.ClientSets{}, &types.ChaosDetails{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default/httpd-0/app: terminated with OOMKilled (exit code 137)"}, restarts)
+
+	restarts, err = getRestartedContainers([]corev1.Pod{pod}, snapshots, clients.ClientSets{}, chaosDetails)
+	require.NoError(t, err)
+	assert.Empty(t, restarts)
+}
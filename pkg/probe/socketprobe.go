@@ -0,0 +1,434 @@
+package probe
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/math"
+	cmp "github.com/figwood/litmus-go/pkg/probe/comparator"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/figwood/litmus-go/pkg/utils/retry"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+// prepareSocketProbe contains the steps to prepare the socket probe
+// socket probe can be used to add the probe which will check the tcp connect or udp request/response for the given endpoints
+func prepareSocketProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+
+	if getProbeInputs(probe.Name, resultDetails.ProbeDetails).SocketProbeInputs == nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeSocketProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "socketProbe/inputs are required for the socket probe"}
+	}
+
+	switch strings.ToLower(phase) {
+	case "prechaos":
+		if err := preChaosSocketProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "postchaos":
+		if err := postChaosSocketProbe(probe, resultDetails, chaosDetails.Delay, chaosDetails.Timeout); err != nil {
+			return err
+		}
+	case "duringchaos":
+		onChaosSocketProbe(probe, resultDetails, clients, chaosDetails)
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeSocketProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("phase '%s' not supported in the socket probe", phase)}
+	}
+	return nil
+}
+
+// triggerSocketProbe run the socket probe against all the endpoints
+func triggerSocketProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) (err error) {
+	defer observeProbe(probe).end(&err)
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := *getProbeInputs(probe.Name, resultDetails.ProbeDetails).SocketProbeInputs
+	protocol := getSocketProtocol(inputs.Protocol)
+
+	if len(inputs.Endpoints) == 0 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeSocketProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "[Probe]: At least one endpoint is required"}
+	}
+	if protocol == "udp" && inputs.Send == "" {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeSocketProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: "[Probe]: send payload is required for the udp protocol"}
+	}
+
+	// It parses the templated endpoints and return normal string
+	// if endpoint doesn't have template, it will return the same endpoint
+	endpoints := make([]string, len(inputs.Endpoints))
+	for index := range inputs.Endpoints {
+		if endpoints[index], err = parseCommand(strings.TrimSpace(inputs.Endpoints[index]), resultDetails); err != nil {
+			return err
+		}
+	}
+
+	var description string
+
+	// it will retry for some retry count, in each iteration of try it contains following things
+	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
+	// for a timeout, it will probe all the endpoints, if it fails wait for the interval and again probe the endpoints until timeout expires
+	if err := retry.Times(uint(getAttempts(probe.RunProperties.Attempt, probe.RunProperties.Retry))).
+		Timeout(probeTimeout.ProbeTimeout).
+		Wait(probeTimeout.Interval).
+		Context(types.AbortContext()).
+		TryWithTimeout(func(attempt uint) error {
+			rc := getAndIncrementRunCount(resultDetails, probe.Name)
+			var latencies []string
+			for _, endpoint := range endpoints {
+				latency, err := probeSocket(protocol, endpoint, inputs, probeTimeout.ProbeTimeout, probe.Name)
+				if err != nil {
+					log.Errorf("The %v socket probe has Failed for %v endpoint, err: %v", probe.Name, endpoint, err)
+					return err
+				}
+				if inputs.Latency != nil {
+					// comparing the latency(in ms) with the expected criteria
+					if err = cmp.RunCount(rc).
+						FirstValue(fmt.Sprintf("%.3f", latency)).
+						SecondValue(inputs.Latency.Value).
+						Criteria(inputs.Latency.Criteria).
+						ProbeName(probe.Name).
+						ProbeVerbosity(probe.RunProperties.Verbosity).
+						CompareFloat(cerrors.FailureTypeSocketProbe); err != nil {
+						log.Errorf("The %v socket probe latency check has Failed for %v endpoint, err: %v", probe.Name, endpoint, err)
+						return err
+					}
+				}
+				latencies = append(latencies, fmt.Sprintf("%s: %.3fms", endpoint, latency))
+			}
+			description = fmt.Sprintf("All the %s endpoints are reachable. Latency: [%s]", protocol, strings.Join(latencies, ", "))
+			return nil
+		}); err != nil {
+		return checkProbeTimeoutError(probe.Name, cerrors.FailureTypeSocketProbe, err)
+	}
+	setProbeDescription(resultDetails, probe, description)
+	return nil
+}
+
+// probeSocket connects to the given endpoint and returns the latency in milliseconds
+// for tcp it is the connect latency and for udp it is the request/response round trip latency
+func probeSocket(protocol, endpoint string, inputs types.SocketProbeInputs, timeout time.Duration, probeName string) (float64, error) {
+	deadline := time.Now().Add(timeout)
+	if timeout == 0 {
+		deadline = time.Time{}
+	}
+
+	startTime := time.Now()
+	conn, err := net.DialTimeout(protocol, endpoint, timeout)
+	if err != nil {
+		return 0, cerrors.Error{ErrorCode: cerrors.FailureTypeSocketProbe, Target: fmt.Sprintf("{name: %v, endpoint: %v}", probeName, endpoint), Reason: fmt.Sprintf("unable to connect, err: %v", err)}
+	}
+	defer conn.Close()
+	latency := float64(time.Since(startTime).Microseconds()) / 1000
+
+	if err := conn.SetDeadline(deadline); err != nil {
+		return 0, cerrors.Error{ErrorCode: cerrors.ErrorTypeSocketProbe, Target: fmt.Sprintf("{name: %v, endpoint: %v}", probeName, endpoint), Reason: err.Error()}
+	}
+
+	if inputs.Send != "" {
+		startTime = time.Now()
+		if _, err := conn.Write([]byte(inputs.Send)); err != nil {
+			return 0, cerrors.Error{ErrorCode: cerrors.FailureTypeSocketProbe, Target: fmt.Sprintf("{name: %v, endpoint: %v}", probeName, endpoint), Reason: fmt.Sprintf("unable to send the payload, err: %v", err)}
+		}
+	}
+
+	switch {
+	case protocol == "udp":
+		// udp is connectionless, the endpoint is reachable only if it responds to the payload
+		buf := make([]byte, 65535)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return 0, cerrors.Error{ErrorCode: cerrors.FailureTypeSocketProbe, Target: fmt.Sprintf("{name: %v, endpoint: %v}", probeName, endpoint), Reason: fmt.Sprintf("no response received, err: %v", err)}
+		}
+		latency = float64(time.Since(startTime).Microseconds()) / 1000
+		if !bytes.Contains(buf[:n], []byte(inputs.Expect)) {
+			return 0, cerrors.Error{ErrorCode: cerrors.FailureTypeSocketProbe, Target: fmt.Sprintf("{name: %v, endpoint: %v}", probeName, endpoint), Reason: fmt.Sprintf("Actual response: %q. Expected response: should contain %q", buf[:n], inputs.Expect)}
+		}
+	case inputs.Expect != "":
+		// reading the banner/response until it contains the expected bytes or the connection is closed
+		var out []byte
+		buf := make([]byte, 4096)
+		for !bytes.Contains(out, []byte(inputs.Expect)) {
+			n, err := conn.Read(buf)
+			out = append(out, buf[:n]...)
+			if err != nil {
+				if err == io.EOF {
+					break
+				}
+				return 0, cerrors.Error{ErrorCode: cerrors.FailureTypeSocketProbe, Target: fmt.Sprintf("{name: %v, endpoint: %v}", probeName, endpoint), Reason: fmt.Sprintf("Actual response: %q. Expected response: should contain %q, err: %v", out, inputs.Expect, err)}
+			}
+		}
+		if !bytes.Contains(out, []byte(inputs.Expect)) {
+			return 0, cerrors.Error{ErrorCode: cerrors.FailureTypeSocketProbe, Target: fmt.Sprintf("{name: %v, endpoint: %v}", probeName, endpoint), Reason: fmt.Sprintf("Actual response: %q. Expected response: should contain %q", out, inputs.Expect)}
+		}
+	}
+	return latency, nil
+}
+
+// getSocketProtocol returns the protocol of the socket probe, default protocol is tcp
+func getSocketProtocol(protocol string) string {
+	if strings.ToLower(protocol) == "udp" {
+		return "udp"
+	}
+	return "tcp"
+}
+
+// triggerContinuousSocketProbe trigger the continuous socket probes
+func triggerContinuousSocketProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+	var isExperimentFailed bool
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+	}
+
+	// it triggers the socket probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+
+loop:
+	for {
+		select {
+		case <-chaosDetails.ProbeContext.Ctx.Done():
+			log.Infof("Stopping %s continuous Probe", probe.Name)
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].HasProbeCompleted = true
+				}
+			}
+			break loop
+		default:
+			err = triggerSocketProbe(probe, chaosresult)
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+						chaosresult.ProbeDetails[index].Status.Description = getDescription(err)
+						log.Errorf("The %v socket probe has been Failed, err: %v", probe.Name, err)
+						isExperimentFailed = true
+						break loop
+					}
+				}
+			}
+			// waiting for the probe polling interval
+			time.Sleep(probeTimeout.ProbePollingInterval)
+		}
+	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
+	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+	// and failed the experiment in the end
+	if isExperimentFailed && probe.RunProperties.StopOnFailure {
+		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("Unable to patch chaosengine to stop, err: %v", err)
+		}
+	}
+}
+
+// preChaosSocketProbe trigger the socket probe for prechaos phase
+func preChaosSocketProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).SocketProbeInputs
+
+	switch probe.Mode {
+	case "SOT", "Edge":
+
+		//DISPLAY THE Socket PROBE INFO
+		log.InfoWithValues("[Probe]: The socket probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Protocol":       getSocketProtocol(inputs.Protocol),
+			"Endpoints":      inputs.Endpoints,
+			"Latency":        inputs.Latency,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		// waiting for initial delay
+		if probeTimeout.InitialDelay != 0 {
+			log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+			time.Sleep(probeTimeout.InitialDelay)
+		}
+		// trigger the socket probe
+		if err = triggerSocketProbe(probe, resultDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeSocketProbe {
+			return err
+		}
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PreChaos"); err != nil {
+			return err
+		}
+	case "Continuous":
+
+		//DISPLAY THE Socket PROBE INFO
+		log.InfoWithValues("[Probe]: The socket probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Protocol":       getSocketProtocol(inputs.Protocol),
+			"Endpoints":      inputs.Endpoints,
+			"Latency":        inputs.Latency,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		go triggerContinuousSocketProbe(probe, clients, resultDetails, chaosDetails)
+
+	}
+	return nil
+}
+
+// postChaosSocketProbe trigger the socket probe for postchaos phase
+func postChaosSocketProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, delay int, timeout int) error {
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).SocketProbeInputs
+
+	switch probe.Mode {
+	case "EOT", "Edge":
+
+		//DISPLAY THE Socket PROBE INFO
+		log.InfoWithValues("[Probe]: The socket probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Protocol":       getSocketProtocol(inputs.Protocol),
+			"Endpoints":      inputs.Endpoints,
+			"Latency":        inputs.Latency,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PostChaos",
+		})
+
+		// waiting for initial delay
+		if probeTimeout.InitialDelay != 0 {
+			log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+			time.Sleep(probeTimeout.InitialDelay)
+		}
+
+		// trigger the socket probe
+		if err = triggerSocketProbe(probe, resultDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeSocketProbe {
+			return err
+		}
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	case "Continuous", "OnChaos":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		if err = checkForErrorInContinuousProbe(resultDetails, probe.Name, delay, timeout); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeSocketProbe && cerrors.GetErrorType(err) != cerrors.FailureTypeProbeTimeout {
+			return err
+		}
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// triggerOnChaosSocketProbe trigger the onchaos socket probes
+func triggerOnChaosSocketProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+
+	var isExperimentFailed bool
+	duration := chaosDetails.ChaosDuration
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+		duration = math.Maximum(0, duration-int(probeTimeout.InitialDelay.Seconds()))
+	}
+
+	endTime := time.After(time.Duration(duration) * time.Second)
+
+	// it trigger the socket probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		select {
+		case <-endTime:
+			log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+			endTime = nil
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].HasProbeCompleted = true
+				}
+			}
+			break loop
+		default:
+			err = triggerSocketProbe(probe, chaosresult)
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+						chaosresult.ProbeDetails[index].Status.Description = getDescription(err)
+						log.Errorf("The %v socket probe has been Failed, err: %v", probe.Name, err)
+						isExperimentFailed = true
+						break loop
+					}
+				}
+			}
+
+			select {
+			case <-chaosDetails.ProbeContext.Ctx.Done():
+				log.Infof("Stopping %s continuous Probe", probe.Name)
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+					}
+				}
+				break loop
+			default:
+				// waiting for the probe polling interval
+				time.Sleep(probeTimeout.ProbePollingInterval)
+			}
+		}
+	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
+	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+	// and failed the experiment in the end
+	if isExperimentFailed && probe.RunProperties.StopOnFailure {
+		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("unable to patch chaosengine to stop, err: %v", err)
+		}
+	}
+}
+
+// onChaosSocketProbe trigger the socket probe for DuringChaos phase
+func onChaosSocketProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).SocketProbeInputs
+
+	switch probe.Mode {
+	case "OnChaos":
+
+		//DISPLAY THE Socket PROBE INFO
+		log.InfoWithValues("[Probe]: The socket probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Protocol":       getSocketProtocol(inputs.Protocol),
+			"Endpoints":      inputs.Endpoints,
+			"Latency":        inputs.Latency,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+		go triggerOnChaosSocketProbe(probe, clients, resultDetails, chaosDetails)
+	}
+
+}
@@ -0,0 +1,475 @@
+package probe
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/math"
+	cmp "github.com/figwood/litmus-go/pkg/probe/comparator"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/figwood/litmus-go/pkg/utils/retry"
+	// registering the mysql and postgres drivers for the sql probe
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// prepareSQLProbe contains the steps to prepare the sql probe
+// sql probe can be used to add the probe which will run a query against the postgres or mysql database and compare the scalar result
+func prepareSQLProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string) error {
+
+	if err := validateSQLProbeInputs(probe.Name, getProbeInputs(probe.Name, resultDetails.ProbeDetails).SQLProbeInputs); err != nil {
+		return err
+	}
+
+	switch strings.ToLower(phase) {
+	case "prechaos":
+		if err := preChaosSQLProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "postchaos":
+		if err := postChaosSQLProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "duringchaos":
+		onChaosSQLProbe(probe, resultDetails, clients, chaosDetails)
+	default:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeSQLProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("phase '%s' not supported in the sql probe", phase)}
+	}
+	return nil
+}
+
+// validateSQLProbeInputs validate the inputs of the sql probe
+func validateSQLProbeInputs(probeName string, inputs *types.SQLProbeInputs) error {
+	switch {
+	case inputs == nil:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeSQLProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "sqlProbe/inputs are required for the sql probe"}
+	case getSQLDriver(inputs.Driver) == "":
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeSQLProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("driver '%s' not supported in the sql probe, use postgres or mysql", inputs.Driver)}
+	case strings.TrimSpace(inputs.DSN) == "" && inputs.DSNFrom.SecretKeyRef == nil:
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeSQLProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "either dsn or dsnFrom.secretKeyRef is required for the sql probe"}
+	case strings.TrimSpace(inputs.Query) == "":
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeSQLProbe, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "query is required for the sql probe"}
+	}
+	return nil
+}
+
+// getSQLDriver returns the name of the registered database driver
+// it returns empty string, if the driver is not supported
+func getSQLDriver(driver string) string {
+	switch strings.ToLower(driver) {
+	case "postgres", "postgresql":
+		return "postgres"
+	case "mysql":
+		return "mysql"
+	}
+	return ""
+}
+
+// getSQLDSN derive the data source name of the database, it uses the plain dsn if provided
+// otherwise it derives the dsn from the secret, the secret should be present inside the chaos namespace
+func getSQLDSN(inputs types.SQLProbeInputs, probeName string, clients clients.ClientSets, chaosNamespace string) (string, error) {
+	if dsn := strings.TrimSpace(inputs.DSN); dsn != "" {
+		log.RegisterSecret(dsn)
+		return dsn, nil
+	}
+	ref := inputs.DSNFrom.SecretKeyRef
+	secret, err := clients.KubeClient.CoreV1().Secrets(chaosNamespace).Get(context.Background(), ref.Name, v1.GetOptions{})
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeSQLProbe, Target: fmt.Sprintf("{name: %v, secret: %v}", probeName, ref.Name), Reason: fmt.Sprintf("unable to get the secret for dsn, err: %v", err)}
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeSQLProbe, Target: fmt.Sprintf("{name: %v, secret: %v}", probeName, ref.Name), Reason: fmt.Sprintf("key '%s' not found inside the secret for dsn", ref.Key)}
+	}
+	dsn := strings.TrimSpace(string(value))
+	log.RegisterSecret(dsn)
+	return dsn, nil
+}
+
+// triggerSQLProbe run the query against the database and compare the scalar result
+func triggerSQLProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) (err error) {
+	defer observeProbe(probe).end(&err)
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := *getProbeInputs(probe.Name, resultDetails.ProbeDetails).SQLProbeInputs
+
+	dsn, err := getSQLDSN(inputs, probe.Name, clients, chaosDetails.ChaosNamespace)
+	if err != nil {
+		return err
+	}
+
+	// It parses the templated query and return normal string
+	// if query doesn't have template, it will return the same query
+	query, err := parseCommand(inputs.Query, resultDetails)
+	if err != nil {
+		return err
+	}
+
+	var description string
+
+	// it will retry for some retry count, in each iteration of try it contains following things
+	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
+	// for a timeout, it will run the query, if it fails wait for the interval and again run the query until timeout expires
+	if err := retry.Times(uint(getAttempts(probe.RunProperties.Attempt, probe.RunProperties.Retry))).
+		Timeout(probeTimeout.ProbeTimeout).
+		Wait(probeTimeout.Interval).
+		Context(types.AbortContext()).
+		TryWithTimeout(func(attempt uint) error {
+			rc := getAndIncrementRunCount(resultDetails, probe.Name)
+			value, err := querySQLScalar(getSQLDriver(inputs.Driver), dsn, query, probeTimeout.ProbeTimeout, probe.Name)
+			if err != nil {
+				log.Errorf("The %v sql probe has Failed, err: %v", probe.Name, err)
+				return err
+			}
+			if err := validateSQLResult(probe, inputs.Comparator, value, rc); err != nil {
+				log.Errorf("The %v sql probe has Failed, err: %v", probe.Name, err)
+				return err
+			}
+			description = fmt.Sprintf("Actual value: '%s'. Expected value: '%s'", value, inputs.Comparator.Value)
+
+			// register the output along with the named outputs, which can be referenced by the later probes
+			return registerProbeOutput(probe, value, []byte(value), resultDetails, cerrors.FailureTypeSQLProbe)
+		}); err != nil {
+		return checkProbeTimeoutError(probe.Name, cerrors.FailureTypeSQLProbe, err)
+	}
+	setProbeDescription(resultDetails, probe, description)
+	return nil
+}
+
+// querySQLScalar runs the query against the database and returns the first column of the first row
+// the null value is returned as empty string
+func querySQLScalar(driver, dsn, query string, timeout time.Duration, probeName string) (string, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.ErrorTypeSQLProbe, Target: fmt.Sprintf("{name: %v, driver: %v}", probeName, driver), Reason: fmt.Sprintf("invalid dsn, err: %v", redactDSN(err, dsn))}
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.FailureTypeSQLProbe, Target: fmt.Sprintf("{name: %v, driver: %v}", probeName, driver), Reason: fmt.Sprintf("unable to run the query, err: %v", redactDSN(err, dsn))}
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", cerrors.Error{ErrorCode: cerrors.FailureTypeSQLProbe, Target: fmt.Sprintf("{name: %v, driver: %v}", probeName, driver), Reason: fmt.Sprintf("unable to read the query result, err: %v", err)}
+		}
+		return "", cerrors.Error{ErrorCode: cerrors.FailureTypeSQLProbe, Target: fmt.Sprintf("{name: %v, driver: %v}", probeName, driver), Reason: "the query returned no rows"}
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.FailureTypeSQLProbe, Target: fmt.Sprintf("{name: %v, driver: %v}", probeName, driver), Reason: fmt.Sprintf("unable to read the query result, err: %v", err)}
+	}
+
+	// only the first column is compared, the other columns are ignored
+	var value sql.NullString
+	dest := make([]interface{}, len(columns))
+	dest[0] = &value
+	for index := 1; index < len(dest); index++ {
+		dest[index] = new(sql.RawBytes)
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return "", cerrors.Error{ErrorCode: cerrors.FailureTypeSQLProbe, Target: fmt.Sprintf("{name: %v, driver: %v}", probeName, driver), Reason: fmt.Sprintf("unable to read the query result, err: %v", err)}
+	}
+	return strings.TrimSpace(value.String), nil
+}
+
+// redactDSN removes the data source name from the error, as it may contain the credentials
+func redactDSN(err error, dsn string) string {
+	if dsn == "" {
+		return err.Error()
+	}
+	return strings.ReplaceAll(err.Error(), dsn, "<dsn>")
+}
+
+// validateSQLResult compare the scalar result of the query with the expected value
+// it uses the string comparator, if the type is not provided
+func validateSQLResult(probe v1alpha1.ProbeAttributes, comparator v1alpha1.ComparatorInfo, value string, rc int) error {
+	compare := cmp.RunCount(rc).
+		FirstValue(value).
+		SecondValue(comparator.Value).
+		Criteria(comparator.Criteria).
+		ProbeName(probe.Name).
+		ProbeVerbosity(probe.RunProperties.Verbosity)
+
+	comparatorType := comparator.Type
+	if comparatorType == "" {
+		comparatorType = "string"
+	}
+	if !cmp.IsSupportedType(comparatorType) {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeSQLProbe, Target: fmt.Sprintf("{name: %v}", probe.Name), Reason: fmt.Sprintf("comparator type '%s' not supported in the sql probe", comparator.Type)}
+	}
+	return compare.Compare(comparatorType, cerrors.FailureTypeSQLProbe)
+}
+
+// triggerContinuousSQLProbe trigger the continuous sql probes
+func triggerContinuousSQLProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+	var isExperimentFailed bool
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+	}
+
+	// it triggers the sql probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+
+loop:
+	for {
+		select {
+		case <-chaosDetails.ProbeContext.Ctx.Done():
+			log.Infof("Stopping %s continuous Probe", probe.Name)
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].HasProbeCompleted = true
+				}
+			}
+			break loop
+		default:
+			err = triggerSQLProbe(probe, clients, chaosresult, chaosDetails)
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+						chaosresult.ProbeDetails[index].Status.Description = getDescription(err)
+						log.Errorf("The %v sql probe has been Failed, err: %v", probe.Name, err)
+						isExperimentFailed = true
+						break loop
+					}
+				}
+			}
+			// waiting for the probe polling interval
+			time.Sleep(probeTimeout.ProbePollingInterval)
+		}
+	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
+	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+	// and failed the experiment in the end
+	if isExperimentFailed && probe.RunProperties.StopOnFailure {
+		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("Unable to patch chaosengine to stop, err: %v", err)
+		}
+	}
+}
+
+// preChaosSQLProbe trigger the sql probe for prechaos phase
+func preChaosSQLProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).SQLProbeInputs
+
+	switch probe.Mode {
+	case "SOT", "Edge":
+
+		//DISPLAY THE SQL PROBE INFO
+		log.InfoWithValues("[Probe]: The sql probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Driver":         inputs.Driver,
+			"Query":          inputs.Query,
+			"Comparator":     inputs.Comparator,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		// waiting for initial delay
+		if probeTimeout.InitialDelay != 0 {
+			log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+			time.Sleep(probeTimeout.InitialDelay)
+		}
+		// trigger the sql probe
+		if err = triggerSQLProbe(probe, clients, resultDetails, chaosDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeSQLProbe {
+			return err
+		}
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PreChaos"); err != nil {
+			return err
+		}
+	case "Continuous":
+
+		//DISPLAY THE SQL PROBE INFO
+		log.InfoWithValues("[Probe]: The sql probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Driver":         inputs.Driver,
+			"Query":          inputs.Query,
+			"Comparator":     inputs.Comparator,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		go triggerContinuousSQLProbe(probe, clients, resultDetails, chaosDetails)
+
+	}
+	return nil
+}
+
+// postChaosSQLProbe trigger the sql probe for postchaos phase
+func postChaosSQLProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	probeTimeout := getProbeTimeouts(probe.Name, resultDetails.ProbeDetails)
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).SQLProbeInputs
+
+	switch probe.Mode {
+	case "EOT", "Edge":
+
+		//DISPLAY THE SQL PROBE INFO
+		log.InfoWithValues("[Probe]: The sql probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Driver":         inputs.Driver,
+			"Query":          inputs.Query,
+			"Comparator":     inputs.Comparator,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PostChaos",
+		})
+
+		// waiting for initial delay
+		if probeTimeout.InitialDelay != 0 {
+			log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+			time.Sleep(probeTimeout.InitialDelay)
+		}
+
+		// trigger the sql probe
+		if err = triggerSQLProbe(probe, clients, resultDetails, chaosDetails); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeSQLProbe {
+			return err
+		}
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	case "Continuous", "OnChaos":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		if err = checkForErrorInContinuousProbe(resultDetails, probe.Name, chaosDetails.Delay, chaosDetails.Timeout); err != nil && cerrors.GetErrorType(err) != cerrors.FailureTypeSQLProbe && cerrors.GetErrorType(err) != cerrors.FailureTypeProbeTimeout {
+			return err
+		}
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = markedVerdictInEnd(err, resultDetails, probe, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// triggerOnChaosSQLProbe trigger the onchaos sql probes
+func triggerOnChaosSQLProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+	probeTimeout := getProbeTimeouts(probe.Name, chaosresult.ProbeDetails)
+
+	var isExperimentFailed bool
+	duration := chaosDetails.ChaosDuration
+	// waiting for initial delay
+	if probeTimeout.InitialDelay != 0 {
+		log.Infof("[Wait]: Waiting for %v before probe execution", probe.RunProperties.InitialDelay)
+		time.Sleep(probeTimeout.InitialDelay)
+		duration = math.Maximum(0, duration-int(probeTimeout.InitialDelay.Seconds()))
+	}
+
+	endTime := time.After(time.Duration(duration) * time.Second)
+
+	// it trigger the sql probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		select {
+		case <-endTime:
+			log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+			endTime = nil
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].HasProbeCompleted = true
+				}
+			}
+			break loop
+		default:
+			err = triggerSQLProbe(probe, clients, chaosresult, chaosDetails)
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				err = addProbePhase(err, string(chaosDetails.Phase))
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+						chaosresult.ProbeDetails[index].Status.Description = getDescription(err)
+						log.Errorf("The %v sql probe has been Failed, err: %v", probe.Name, err)
+						isExperimentFailed = true
+						break loop
+					}
+				}
+			}
+
+			select {
+			case <-chaosDetails.ProbeContext.Ctx.Done():
+				log.Infof("Stopping %s continuous Probe", probe.Name)
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].HasProbeCompleted = true
+					}
+				}
+				break loop
+			default:
+				// waiting for the probe polling interval
+				time.Sleep(probeTimeout.ProbePollingInterval)
+			}
+		}
+	}
+	// if experiment fails and the probe is provided as halt probe then it will revert the chaos immediately
+	if isExperimentFailed {
+		haltChaos(probe, clients, chaosresult, chaosDetails)
+	}
+	// if experiment fails and stopOnfailure is provided as true then it will patch the chaosengine for abort
+	// if experiment fails but stopOnfailure is provided as false then it will continue the execution
+	// and failed the experiment in the end
+	if isExperimentFailed && probe.RunProperties.StopOnFailure {
+		if err := stopChaosEngine(probe, clients, chaosresult, chaosDetails); err != nil {
+			log.Errorf("unable to patch chaosengine to stop, err: %v", err)
+		}
+	}
+}
+
+// onChaosSQLProbe trigger the sql probe for DuringChaos phase
+func onChaosSQLProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	inputs := getProbeInputs(probe.Name, resultDetails.ProbeDetails).SQLProbeInputs
+
+	switch probe.Mode {
+	case "OnChaos":
+
+		//DISPLAY THE SQL PROBE INFO
+		log.InfoWithValues("[Probe]: The sql probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Driver":         inputs.Driver,
+			"Query":          inputs.Query,
+			"Comparator":     inputs.Comparator,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+		go triggerOnChaosSQLProbe(probe, clients, resultDetails, chaosDetails)
+	}
+
+}
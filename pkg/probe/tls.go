@@ -0,0 +1,72 @@
+package probe
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/types"
+)
+
+// getTLSConfig derive the tls config from the tls inputs of the probe
+// the ca bundle and client certificates are read from the files mounted inside the experiment pod
+func getTLSConfig(inputs *types.TLSInputs, probeName string, errorCode cerrors.ErrorType) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: inputs.InsecureSkipVerify,
+		ServerName:         inputs.ServerName,
+	}
+
+	if inputs.CACertPath != "" {
+		caCert, err := os.ReadFile(inputs.CACertPath)
+		if err != nil {
+			return nil, cerrors.Error{ErrorCode: errorCode, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to read the ca bundle, err: %v", err)}
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, cerrors.Error{ErrorCode: errorCode, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("no valid certificate found inside the ca bundle '%s'", inputs.CACertPath)}
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	switch {
+	case inputs.ClientCertPath != "" && inputs.ClientKeyPath != "":
+		clientCert, err := tls.LoadX509KeyPair(inputs.ClientCertPath, inputs.ClientKeyPath)
+		if err != nil {
+			return nil, cerrors.Error{ErrorCode: errorCode, Target: fmt.Sprintf("{name: %v}", probeName), Reason: fmt.Sprintf("unable to load the client certificate, err: %v", err)}
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	case inputs.ClientCertPath != "" || inputs.ClientKeyPath != "":
+		return nil, cerrors.Error{ErrorCode: errorCode, Target: fmt.Sprintf("{name: %v}", probeName), Reason: "both clientCertPath and clientKeyPath are required for the client certificate"}
+	}
+
+	return tlsConfig, nil
+}
+
+// getTLSErrorReason returns the reason of the tls handshake failure along with the possible fix
+// it returns false if the error is not caused by the tls handshake
+func getTLSErrorReason(err error) (string, bool) {
+	var (
+		unknownAuthorityErr x509.UnknownAuthorityError
+		hostnameErr         x509.HostnameError
+		certificateErr      x509.CertificateInvalidError
+		recordHeaderErr     tls.RecordHeaderError
+	)
+
+	switch {
+	case errors.As(err, &unknownAuthorityErr):
+		return fmt.Sprintf("server certificate is signed by an unknown authority, provide the ca bundle via caCertPath, err: %v", unknownAuthorityErr), true
+	case errors.As(err, &hostnameErr):
+		return fmt.Sprintf("server certificate is not valid for the host, override the server name via serverName, err: %v", hostnameErr), true
+	case errors.As(err, &certificateErr):
+		return fmt.Sprintf("server certificate is invalid, err: %v", certificateErr), true
+	case errors.As(err, &recordHeaderErr):
+		return fmt.Sprintf("server did not respond with tls, err: %v", recordHeaderErr), true
+	case strings.Contains(err.Error(), "remote error: tls:"):
+		return fmt.Sprintf("server rejected the handshake, verify the client certificate via clientCertPath and clientKeyPath, err: %v", errors.Unwrap(err)), true
+	}
+	return "", false
+}
@@ -13,11 +13,15 @@ import (
 	"github.com/palantir/stacktrace"
 
 	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"go.opentelemetry.io/otel/attribute"
 
 	clients "github.com/figwood/litmus-go/pkg/clients"
 	"github.com/figwood/litmus-go/pkg/events"
 	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/metrics"
+	"github.com/figwood/litmus-go/pkg/notify"
 	"github.com/figwood/litmus-go/pkg/probe"
+	"github.com/figwood/litmus-go/pkg/tracing"
 	"github.com/figwood/litmus-go/pkg/types"
 	"github.com/figwood/litmus-go/pkg/utils/retry"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -25,9 +29,32 @@ import (
 )
 
 // ChaosResult Create and Update the chaos result
+// the verdict of the aborted experiment is recorded only by the abort watcher, via RecordAfterAbort
 func ChaosResult(chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, state string) error {
+	if state == "EOT" && types.IsAborted() {
+		types.WaitForAbortRecord()
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeExperimentAborted, Target: fmt.Sprintf("{name: %s, namespace: %s}", resultDetails.Name, chaosDetails.ChaosNamespace), Reason: "experiment is aborted, the verdict is recorded by the abort watcher"}
+	}
+	return updateChaosResult(chaosDetails, clients, resultDetails, state)
+}
+
+// RecordAfterAbort updates the chaosresult of the aborted experiment at the end of test
+// it is used only by the abort watcher, the other updates of the verdict are skipped once the experiment is aborted
+func RecordAfterAbort(chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+	return updateChaosResult(chaosDetails, clients, resultDetails, "EOT")
+}
+
+// updateChaosResult Create and Update the chaos result
+func updateChaosResult(chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, state string) error {
 	experimentLabel := map[string]string{}
 
+	// writing the run report once the verdict is finalised, even if the chaosresult update fails
+	if state == "EOT" {
+		defer WriteReport(chaosDetails, resultDetails)
+		defer pushMetrics(resultDetails)
+		defer endTrace(resultDetails)
+	}
+
 	// It tries to get the chaosresult, if available
 	// it will retry until it got chaos result or met the timeout(3 mins)
 	isResultAvailable := false
@@ -213,6 +240,9 @@ func updateResultAttributes(clients clients.ClientSets, chaosDetails *types.Chao
 	return result, nil
 }
 
+// maxRetryWait is the maximum wait duration between the retries of the chaosresult update
+const maxRetryWait = 30 * time.Second
+
 // PatchChaosResult Update the chaos result
 func PatchChaosResult(clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, chaosResultLabel map[string]string) error {
 
@@ -222,10 +252,14 @@ func PatchChaosResult(clients clients.ClientSets, chaosDetails *types.ChaosDetai
 	}
 
 	// It will update the existing chaos-result CR with new values
-	// it will retry until it will be able to update successfully or met the timeout(3 mins)
+	// it will retry with the exponential backoff until it will be able to update successfully or met the timeout(3 mins)
+	// the non-retryable api errors are returned immediately
 	return retry.
-		Times(uint(chaosDetails.Timeout / chaosDetails.Delay)).
-		Wait(time.Duration(chaosDetails.Delay) * time.Second).
+		Times(uint(chaosDetails.Timeout/chaosDetails.Delay)).
+		Wait(time.Duration(chaosDetails.Delay)*time.Second).
+		Backoff(2, maxRetryWait).
+		Jitter(0.2).
+		MaxElapsed(time.Duration(chaosDetails.Timeout) * time.Second).
 		Try(func(attempt uint) error {
 			_, updateErr := clients.LitmusClient.ChaosResults(result.Namespace).Update(context.Background(), result, v1.UpdateOptions{})
 			if updateErr != nil {
@@ -235,7 +269,11 @@ func PatchChaosResult(clients clients.ClientSets, chaosDetails *types.ChaosDetai
 						return stacktrace.Propagate(err, "could not update chaosresult attributes")
 					}
 				}
-				return cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosResultCRUD, Phase: getExperimentPhaseFromResultPhase(resultDetails.Phase), Target: fmt.Sprintf("{name: %s, namespace: %s}", resultDetails.Name, chaosDetails.ChaosNamespace), Reason: updateErr.Error()}
+				crudErr := cerrors.Error{ErrorCode: cerrors.ErrorTypeChaosResultCRUD, Phase: getExperimentPhaseFromResultPhase(resultDetails.Phase), Target: fmt.Sprintf("{name: %s, namespace: %s}", resultDetails.Name, chaosDetails.ChaosNamespace), Reason: updateErr.Error()}
+				if !retry.IsRetryableAPIError(updateErr) {
+					return retry.Permanent(crudErr)
+				}
+				return crudErr
 			}
 			return nil
 		})
@@ -254,8 +292,23 @@ func SetResultUID(resultDetails *types.ResultDetails, clients clients.ClientSets
 }
 
 // RecordAfterFailure update the chaosresult and create the summary events
+// the failures of the aborted experiment are not recorded, it waits for the abort watcher to record the verdict
 func RecordAfterFailure(chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, err error, clients clients.ClientSets, eventsDetails *types.EventDetails) {
+	if types.IsAborted() {
+		log.Infof("[Abort]: Skipping the failure as the experiment is aborted, err: %v", err)
+		types.WaitForAbortRecord()
+		return
+	}
+
+	// cleanup the source pods and resources of the probes, as the postchaos probes are not executed after the failure
+	if err := probe.CleanupProbes(resultDetails, chaosDetails, clients); err != nil {
+		log.Errorf("failed to cleanup the probes, err: %v", err)
+	}
+
 	failStep, errorCode := cerrors.GetRootCauseAndErrorCode(err, string(chaosDetails.Phase))
+	if errorCode == cerrors.ErrorTypeChaosRevert {
+		metrics.RecordRevertFailure()
+	}
 	phase := v1alpha1.ResultPhaseError
 	verdict := v1alpha1.ResultVerdictError
 	if probe.IsProbeFailed(failStep) {
@@ -300,6 +353,14 @@ func updateHistory(result *v1alpha1.ChaosResult) {
 // AnnotateChaosResult annotate the chaosResult for the chaos status
 // using kubectl cli to annotate the chaosresult as it will automatically handle the race condition in case of multiple helpers
 func AnnotateChaosResult(resultName, namespace, status, kind, name string) error {
+	// recording the injection and revert time of the target, the helper pods push the metrics after each update
+	metrics.RecordTargetStatus(name, kind, status)
+	tracing.RecordTargetStatus(name, kind, status)
+	notify.RecordTargetStatus(name, kind, status)
+	if err := metrics.Push(); err != nil {
+		log.Errorf("[Metrics]: Unable to push the metrics, err: %v", err)
+	}
+
 	command := exec.Command("kubectl", "annotate", "chaosresult", resultName, "-n", namespace, kind+"/"+name+"="+status, "--overwrite")
 	var out, stderr bytes.Buffer
 	command.Stdout = &out
@@ -343,9 +404,21 @@ func GetChaosStatus(resultDetails *types.ResultDetails, chaosDetails *types.Chao
 
 func UpdateFailedStepFromHelper(resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, client clients.ClientSets, err error) error {
 	rootCause, errCode := cerrors.GetRootCauseAndErrorCode(err, string(chaosDetails.Phase))
+	if errCode == cerrors.ErrorTypeChaosRevert {
+		metrics.RecordRevertFailure()
+	}
+	if err := metrics.Push(); err != nil {
+		log.Errorf("[Metrics]: Unable to push the metrics, err: %v", err)
+	}
+	// ending the trace of the helper pod, as the helper pod exits after the failure
+	tracing.End(string(errCode), attribute.String("chaos.fail_step", rootCause))
 	return retry.
-		Times(uint(chaosDetails.Timeout / chaosDetails.Delay)).
-		Wait(time.Duration(chaosDetails.Delay) * time.Second).
+		Times(uint(chaosDetails.Timeout/chaosDetails.Delay)).
+		Wait(time.Duration(chaosDetails.Delay)*time.Second).
+		Backoff(2, maxRetryWait).
+		Jitter(0.2).
+		MaxElapsed(time.Duration(chaosDetails.Timeout) * time.Second).
+		RetryIf(retry.IsRetryableAPIError).
 		Try(func(attempt uint) error {
 			chaosResult, err := client.LitmusClient.ChaosResults(chaosDetails.ChaosNamespace).Get(context.Background(), resultDetails.Name, v1.GetOptions{})
 			if err != nil {
@@ -364,6 +437,23 @@ func UpdateFailedStepFromHelper(resultDetails *types.ResultDetails, chaosDetails
 		})
 }
 
+// pushMetrics sets the verdict of the experiment and pushes the metrics at the end of the run
+func pushMetrics(resultDetails *types.ResultDetails) {
+	metrics.SetVerdict(string(resultDetails.Verdict))
+	if err := metrics.Push(); err != nil {
+		log.Errorf("[Metrics]: Unable to push the metrics, err: %v", err)
+	}
+}
+
+// endTrace ends the trace of the experiment along with the verdict and fail step
+func endTrace(resultDetails *types.ResultDetails) {
+	tracing.End(string(resultDetails.ErrorCode),
+		attribute.String("chaos.verdict", string(resultDetails.Verdict)),
+		attribute.String("chaos.result.phase", string(resultDetails.Phase)),
+		attribute.String("chaos.fail_step", resultDetails.FailStep),
+	)
+}
+
 func appendErrorOutput(failStep string, rootCause string) string {
 	failStep = strings.TrimPrefix(failStep, "[")
 	failStep = strings.TrimSuffix(failStep, "]")
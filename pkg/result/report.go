@@ -0,0 +1,333 @@
+package result
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/probe"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+)
+
+// injectionTestCase is the name of the junit testcase of the chaos injection
+const injectionTestCase = "chaos-injection"
+
+// runReport contains the summary of the experiment run
+type runReport struct {
+	ExperimentName string                   `json:"experimentName"`
+	EngineName     string                   `json:"engineName,omitempty"`
+	Namespace      string                   `json:"namespace"`
+	InstanceID     string                   `json:"instanceID,omitempty"`
+	ResultName     string                   `json:"resultName"`
+	Verdict        string                   `json:"verdict"`
+	Phase          string                   `json:"phase"`
+	FailStep       string                   `json:"failStep,omitempty"`
+	ErrorCode      string                   `json:"errorCode,omitempty"`
+	StartTime      time.Time                `json:"startTime"`
+	EndTime        time.Time                `json:"endTime"`
+	Duration       string                   `json:"duration"`
+	Phases         []phaseReport            `json:"phases"`
+	Probes         []probeReport            `json:"probes"`
+	Targets        []v1alpha1.TargetDetails `json:"targets"`
+}
+
+// phaseReport contains the timings of the experiment phase
+type phaseReport struct {
+	Phase     string    `json:"phase"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Duration  string    `json:"duration"`
+	duration  time.Duration
+}
+
+// probeReport contains the verdict of the probe
+type probeReport struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Mode        string   `json:"mode"`
+	Verdict     string   `json:"verdict"`
+	Description string   `json:"description,omitempty"`
+	Artifacts   []string `json:"artifacts,omitempty"`
+}
+
+// WriteReport writes the run report in all the formats provided via REPORT_FORMAT env
+// it is written at the end of every run, including the failed and aborted runs
+func WriteReport(chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) {
+	if strings.TrimSpace(chaosDetails.ReportFormat) == "" {
+		return
+	}
+	report := getRunReport(chaosDetails, resultDetails, time.Now())
+
+	for _, format := range strings.Split(chaosDetails.ReportFormat, ",") {
+		var data []byte
+		var ext string
+		var err error
+		switch strings.ToLower(strings.TrimSpace(format)) {
+		case "":
+			continue
+		case "json":
+			ext = "json"
+			data, err = json.MarshalIndent(report, "", "  ")
+		case "junit":
+			ext = "xml"
+			data, err = report.junit()
+		case "markdown", "md":
+			ext = "md"
+			data = report.markdown()
+		default:
+			log.Errorf("[Report]: %v report format not supported, use json, junit or markdown", format)
+			continue
+		}
+		if err != nil {
+			log.Errorf("[Report]: Unable to generate the %v report, err: %v", format, err)
+			continue
+		}
+
+		path := filepath.Join(chaosDetails.ReportPath, resultDetails.Name+"-report."+ext)
+		if err := os.MkdirAll(chaosDetails.ReportPath, 0755); err != nil {
+			log.Errorf("[Report]: Unable to create the report directory, err: %v", err)
+			return
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			log.Errorf("[Report]: Unable to write the %v report, err: %v", format, err)
+			continue
+		}
+		log.Infof("[Report]: The %v report has been written to %v", format, path)
+	}
+}
+
+// getRunReport derive the run report from the chaos and result details
+// the endTime is the end time of the run and its last phase
+func getRunReport(chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, endTime time.Time) runReport {
+	report := runReport{
+		ExperimentName: chaosDetails.ExperimentName,
+		EngineName:     chaosDetails.EngineName,
+		Namespace:      chaosDetails.ChaosNamespace,
+		InstanceID:     chaosDetails.InstanceID,
+		ResultName:     resultDetails.Name,
+		Verdict:        string(resultDetails.Verdict),
+		Phase:          string(resultDetails.Phase),
+		FailStep:       resultDetails.FailStep,
+		ErrorCode:      string(resultDetails.ErrorCode),
+		StartTime:      endTime,
+		EndTime:        endTime,
+		Targets:        chaosDetails.Targets,
+	}
+
+	for index, timing := range chaosDetails.PhaseTimings {
+		phaseEndTime := endTime
+		if index+1 < len(chaosDetails.PhaseTimings) {
+			phaseEndTime = chaosDetails.PhaseTimings[index+1].StartTime
+		}
+		duration := phaseEndTime.Sub(timing.StartTime)
+		report.Phases = append(report.Phases, phaseReport{
+			Phase:     string(timing.Phase),
+			StartTime: timing.StartTime,
+			EndTime:   phaseEndTime,
+			Duration:  duration.Round(time.Millisecond).String(),
+			duration:  duration,
+		})
+	}
+	if len(chaosDetails.PhaseTimings) != 0 {
+		report.StartTime = chaosDetails.PhaseTimings[0].StartTime
+	}
+	report.Duration = endTime.Sub(report.StartTime).Round(time.Millisecond).String()
+
+	for _, probeDetails := range resultDetails.ProbeDetails {
+		verdict, description := probeDetails.Status.Verdict, probeDetails.Status.Description
+		// the probes which are not evaluated are marked as N/A, same as the chaosresult
+		if verdict == v1alpha1.ProbeVerdictAwaited || verdict == "" {
+			verdict, description = v1alpha1.ProbeVerdictNA, "Either probe is not executed or not evaluated"
+		}
+		report.Probes = append(report.Probes, probeReport{
+			Name:        probeDetails.Name,
+			Type:        probeDetails.Type,
+			Mode:        probeDetails.Mode,
+			Verdict:     string(verdict),
+			Description: description,
+			Artifacts:   probeDetails.Artifacts,
+		})
+	}
+	return report
+}
+
+// phaseDuration returns the total duration of the given phase
+func (report runReport) phaseDuration(phase types.ExperimentPhase) time.Duration {
+	var duration time.Duration
+	for _, timing := range report.Phases {
+		if timing.Phase == string(phase) {
+			duration += timing.duration
+		}
+	}
+	return duration
+}
+
+// junitTestSuites is the root element of the junit report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite contains the testcases of the experiment run
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+// junitProperty contains the property of the testsuite
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitTestCase contains the outcome of the chaos injection or a probe
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+// junitMessage contains the details of the failed, errored or skipped testcase
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// junit returns the report in junit xml format
+// it contains one testcase for the chaos injection and one testcase per probe
+func (report runReport) junit() ([]byte, error) {
+	suite := junitTestSuite{
+		Name:      report.ExperimentName,
+		Time:      fmt.Sprintf("%.3f", report.EndTime.Sub(report.StartTime).Seconds()),
+		Timestamp: report.StartTime.UTC().Format(time.RFC3339),
+		Properties: []junitProperty{
+			{Name: "verdict", Value: report.Verdict},
+			{Name: "phase", Value: report.Phase},
+			{Name: "engine", Value: report.EngineName},
+			{Name: "namespace", Value: report.Namespace},
+		},
+	}
+
+	// the probe failures are reported by the testcases of the probes
+	injection := junitTestCase{
+		Name:      injectionTestCase,
+		ClassName: report.ExperimentName,
+		Time:      fmt.Sprintf("%.3f", report.phaseDuration(types.ChaosInjectPhase).Seconds()),
+	}
+	switch {
+	case report.Phase == string(v1alpha1.ResultPhaseStopped):
+		injection.Failure = &junitMessage{Message: report.FailStep, Type: report.ErrorCode, Text: report.FailStep}
+	case report.FailStep != "" && !probe.IsProbeFailed(report.FailStep) && !probe.IsProbeFailed(report.ErrorCode):
+		injection.Error = &junitMessage{Message: report.FailStep, Type: report.ErrorCode, Text: report.FailStep}
+	}
+	suite.TestCases = append(suite.TestCases, injection)
+
+	for _, probeReport := range report.Probes {
+		testCase := junitTestCase{
+			Name:      probeReport.Name,
+			ClassName: report.ExperimentName + "." + probeReport.Type,
+			Time:      "0.000",
+		}
+		text := strings.Join(append([]string{probeReport.Description}, probeReport.Artifacts...), "\n")
+		switch v1alpha1.ProbeVerdict(probeReport.Verdict) {
+		case v1alpha1.ProbeVerdictPassed:
+		case v1alpha1.ProbeVerdictFailed:
+			testCase.Failure = &junitMessage{Message: probeReport.Description, Type: probeReport.Mode, Text: text}
+		default:
+			testCase.Skipped = &junitMessage{Message: probeReport.Description}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	for _, testCase := range suite.TestCases {
+		suite.Tests++
+		switch {
+		case testCase.Failure != nil:
+			suite.Failures++
+		case testCase.Error != nil:
+			suite.Errors++
+		case testCase.Skipped != nil:
+			suite.Skipped++
+		}
+	}
+
+	suites := junitTestSuites{
+		Name:     "litmus",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to marshal the junit report: %v", err)}
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// markdown returns the report in markdown format
+func (report runReport) markdown() []byte {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "# %s experiment report\n\n", report.ExperimentName)
+	fmt.Fprintf(&out, "| Verdict | Phase | Engine | Namespace | Started | Duration |\n")
+	fmt.Fprintf(&out, "|---|---|---|---|---|---|\n")
+	fmt.Fprintf(&out, "| %s | %s | %s | %s | %s | %s |\n\n", markdownCell(report.Verdict), markdownCell(report.Phase), markdownCell(report.EngineName),
+		markdownCell(report.Namespace), report.StartTime.UTC().Format(time.RFC3339), report.Duration)
+	if report.FailStep != "" {
+		fmt.Fprintf(&out, "**Fail step:** %s (`%s`)\n\n", markdownCell(report.FailStep), report.ErrorCode)
+	}
+
+	fmt.Fprintf(&out, "## Phases\n\n| Phase | Started | Duration |\n|---|---|---|\n")
+	for _, timing := range report.Phases {
+		fmt.Fprintf(&out, "| %s | %s | %s |\n", timing.Phase, timing.StartTime.UTC().Format(time.RFC3339), timing.Duration)
+	}
+
+	if len(report.Probes) != 0 {
+		fmt.Fprintf(&out, "\n## Probes\n\n| Name | Type | Mode | Verdict | Description |\n|---|---|---|---|---|\n")
+		for _, probeReport := range report.Probes {
+			fmt.Fprintf(&out, "| %s | %s | %s | %s | %s |\n", markdownCell(probeReport.Name), probeReport.Type, probeReport.Mode,
+				probeReport.Verdict, markdownCell(probeReport.Description))
+		}
+	}
+
+	if len(report.Targets) != 0 {
+		fmt.Fprintf(&out, "\n## Targets\n\n| Name | Kind | Chaos status |\n|---|---|---|\n")
+		for _, target := range report.Targets {
+			fmt.Fprintf(&out, "| %s | %s | %s |\n", markdownCell(target.Name), target.Kind, target.ChaosStatus)
+		}
+	}
+	return out.Bytes()
+}
+
+// markdownCell escapes the value to fit inside the markdown table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,114 @@
+package result
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update regenerates the golden files, e.g. go test ./pkg/result/ -update
+var update = flag.Bool("update", false, "update the golden files")
+
+var reportStartTime = time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+// getReportDetails returns the chaos and result details of the run, along with its end time
+func getReportDetails(phase v1alpha1.ResultPhase, verdict v1alpha1.ResultVerdict, failStep string, errorCode cerrors.ErrorType) (*types.ChaosDetails, *types.ResultDetails, time.Time) {
+	chaosDetails := &types.ChaosDetails{
+		ExperimentName: "pod-delete",
+		EngineName:     "nginx-chaos",
+		ChaosNamespace: "litmus",
+		InstanceID:     "run-1",
+		PhaseTimings: []types.PhaseTiming{
+			{Phase: types.PreChaosPhase, StartTime: reportStartTime},
+			{Phase: types.ChaosInjectPhase, StartTime: reportStartTime.Add(5 * time.Second)},
+			{Phase: types.PostChaosPhase, StartTime: reportStartTime.Add(65*time.Second + 250*time.Millisecond)},
+		},
+		Targets: []v1alpha1.TargetDetails{
+			{Name: "nginx-7d8f9c-abcde", Kind: "pod", ChaosStatus: "targeted"},
+		},
+	}
+	resultDetails := &types.ResultDetails{
+		Name:      "nginx-chaos-pod-delete",
+		Phase:     phase,
+		Verdict:   verdict,
+		FailStep:  failStep,
+		ErrorCode: errorCode,
+		ProbeDetails: []*types.ProbeDetails{
+			{Name: "http-health", Type: "httpProbe", Mode: "Continuous", Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictPassed, Description: "The URL http://nginx.default.svc did meet the slo"}},
+			{Name: "log-errors", Type: "logProbe", Mode: "OnChaos", Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictFailed, Description: "matched 2 lines of 'panic|fatal', expected at most 0"},
+				Artifacts: []string{"nginx-7d8f9c-fghij/nginx: panic: <nil> & \"unexpected\"", "nginx-7d8f9c-fghij/nginx: fatal error"}},
+			{Name: "cmd-version", Type: "cmdProbe", Mode: "EOT", Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictAwaited}},
+		},
+	}
+	return chaosDetails, resultDetails, reportStartTime.Add(75 * time.Second)
+}
+
+func TestReportGolden(t *testing.T) {
+	scenarios := []struct {
+		name      string
+		phase     v1alpha1.ResultPhase
+		verdict   v1alpha1.ResultVerdict
+		failStep  string
+		errorCode cerrors.ErrorType
+	}{
+		// the probe failure is reported by the probe testcase only
+		{name: "probe-failed", phase: v1alpha1.ResultPhaseCompleted, verdict: v1alpha1.ResultVerdictFailed,
+			failStep: "[{probe: log-errors} LOG_PROBE_FAILURE matched 2 lines of 'panic|fatal']", errorCode: cerrors.FailureTypeLogProbe},
+		// the injection failure is reported as the error of the injection testcase
+		{name: "injection-error", phase: v1alpha1.ResultPhaseCompleted, verdict: v1alpha1.ResultVerdictFailed,
+			failStep: "helper pod failed: container-kill | exit code 1", errorCode: cerrors.ErrorTypeHelper},
+		// the aborted run is reported as the failure of the injection testcase
+		{name: "stopped", phase: v1alpha1.ResultPhaseStopped, verdict: v1alpha1.ResultVerdictStopped,
+			failStep: "Chaos injection stopped!", errorCode: cerrors.ErrorTypeExperimentAborted},
+	}
+	formats := []struct {
+		ext    string
+		render func(report runReport) ([]byte, error)
+	}{
+		{ext: "json", render: func(report runReport) ([]byte, error) { return json.MarshalIndent(report, "", "  ") }},
+		{ext: "xml", render: runReport.junit},
+		{ext: "md", render: func(report runReport) ([]byte, error) { return report.markdown(), nil }},
+	}
+
+	for _, scenario := range scenarios {
+		for _, format := range formats {
+			t.Run(scenario.name+"."+format.ext, func(t *testing.T) {
+				chaosDetails, resultDetails, endTime := getReportDetails(scenario.phase, scenario.verdict, scenario.failStep, scenario.errorCode)
+				got, err := format.render(getRunReport(chaosDetails, resultDetails, endTime))
+				require.NoError(t, err)
+
+				golden := filepath.Join("testdata", scenario.name+"-report."+format.ext)
+				if *update {
+					require.NoError(t, os.WriteFile(golden, got, 0644))
+				}
+				want, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				assert.Equal(t, string(want), string(got))
+			})
+		}
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	chaosDetails, resultDetails, _ := getReportDetails(v1alpha1.ResultPhaseCompleted, v1alpha1.ResultVerdictPassed, "", "")
+	chaosDetails.ReportPath = filepath.Join(t.TempDir(), "reports")
+	chaosDetails.ReportFormat = "json, junit,markdown,yaml,"
+
+	WriteReport(chaosDetails, resultDetails)
+
+	for _, name := range []string{"nginx-chaos-pod-delete-report.json", "nginx-chaos-pod-delete-report.xml", "nginx-chaos-pod-delete-report.md"} {
+		assert.FileExists(t, filepath.Join(chaosDetails.ReportPath, name))
+	}
+	entries, err := os.ReadDir(chaosDetails.ReportPath)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
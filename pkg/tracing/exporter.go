@@ -0,0 +1,224 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// otlpExporter exports the spans to the otlp endpoint, using the json encoding of otlp/http
+type otlpExporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+// newOTLPExporter returns the exporter for the given traces endpoint
+// the headers are provided in the OTEL_EXPORTER_OTLP_HEADERS format, e.g. key1=value1,key2=value2
+func newOTLPExporter(endpoint, headers string) *otlpExporter {
+	exporter := &otlpExporter{
+		endpoint: endpoint,
+		headers:  map[string]string{},
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, header := range strings.Split(headers, ",") {
+		if key, value, ok := strings.Cut(header, "="); ok && strings.TrimSpace(key) != "" {
+			exporter.headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return exporter
+}
+
+// ExportSpans exports the batch of spans to the otlp endpoint
+func (e *otlpExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(encodeSpans(spans))
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("failed to encode the spans: %v", err)}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{endpoint: %s}", e.endpoint), Reason: fmt.Sprintf("failed to create the export request: %v", err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{endpoint: %s}", e.endpoint), Reason: fmt.Sprintf("failed to export the spans: %v", err)}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Target: fmt.Sprintf("{endpoint: %s}", e.endpoint), Reason: fmt.Sprintf("failed to export the spans, status code: %d", resp.StatusCode)}
+	}
+	return nil
+}
+
+// Shutdown shuts down the exporter, there is nothing to release
+func (e *otlpExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// otlp json schema of the export request
+type (
+	exportRequest struct {
+		ResourceSpans []resourceSpans `json:"resourceSpans"`
+	}
+	resourceSpans struct {
+		Resource   resourceAttributes `json:"resource"`
+		ScopeSpans []scopeSpans       `json:"scopeSpans"`
+	}
+	resourceAttributes struct {
+		Attributes []keyValue `json:"attributes"`
+	}
+	scopeSpans struct {
+		Scope scope  `json:"scope"`
+		Spans []span `json:"spans"`
+	}
+	scope struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+	span struct {
+		TraceID           string     `json:"traceId"`
+		SpanID            string     `json:"spanId"`
+		ParentSpanID      string     `json:"parentSpanId,omitempty"`
+		Name              string     `json:"name"`
+		Kind              int        `json:"kind"`
+		StartTimeUnixNano string     `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+		Attributes        []keyValue `json:"attributes,omitempty"`
+		Events            []event    `json:"events,omitempty"`
+		Status            status     `json:"status"`
+	}
+	event struct {
+		TimeUnixNano string     `json:"timeUnixNano"`
+		Name         string     `json:"name"`
+		Attributes   []keyValue `json:"attributes,omitempty"`
+	}
+	status struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+	keyValue struct {
+		Key   string   `json:"key"`
+		Value anyValue `json:"value"`
+	}
+	anyValue struct {
+		StringValue *string     `json:"stringValue,omitempty"`
+		BoolValue   *bool       `json:"boolValue,omitempty"`
+		IntValue    *string     `json:"intValue,omitempty"`
+		DoubleValue *float64    `json:"doubleValue,omitempty"`
+		ArrayValue  *arrayValue `json:"arrayValue,omitempty"`
+	}
+	arrayValue struct {
+		Values []anyValue `json:"values"`
+	}
+)
+
+// encodeSpans returns the export request of the spans, grouped by the resource and instrumentation scope
+func encodeSpans(spans []sdktrace.ReadOnlySpan) exportRequest {
+	request := exportRequest{}
+	for _, s := range spans {
+		resource := encodeAttributes(s.Resource().Attributes())
+		scopeName, scopeVersion := s.InstrumentationScope().Name, s.InstrumentationScope().Version
+		if len(request.ResourceSpans) == 0 {
+			request.ResourceSpans = append(request.ResourceSpans, resourceSpans{Resource: resourceAttributes{Attributes: resource}})
+		}
+		// all the spans of the process share the same resource
+		rs := &request.ResourceSpans[0]
+		var ss *scopeSpans
+		for index := range rs.ScopeSpans {
+			if rs.ScopeSpans[index].Scope.Name == scopeName && rs.ScopeSpans[index].Scope.Version == scopeVersion {
+				ss = &rs.ScopeSpans[index]
+			}
+		}
+		if ss == nil {
+			rs.ScopeSpans = append(rs.ScopeSpans, scopeSpans{Scope: scope{Name: scopeName, Version: scopeVersion}})
+			ss = &rs.ScopeSpans[len(rs.ScopeSpans)-1]
+		}
+
+		encoded := span{
+			TraceID:           s.SpanContext().TraceID().String(),
+			SpanID:            s.SpanContext().SpanID().String(),
+			Name:              s.Name(),
+			Kind:              int(s.SpanKind()),
+			StartTimeUnixNano: strconv.FormatInt(s.StartTime().UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.EndTime().UnixNano(), 10),
+			Attributes:        encodeAttributes(s.Attributes()),
+			Status:            status{Code: encodeStatusCode(s.Status().Code), Message: s.Status().Description},
+		}
+		if s.Parent().IsValid() {
+			encoded.ParentSpanID = s.Parent().SpanID().String()
+		}
+		for _, e := range s.Events() {
+			encoded.Events = append(encoded.Events, event{
+				TimeUnixNano: strconv.FormatInt(e.Time.UnixNano(), 10),
+				Name:         e.Name,
+				Attributes:   encodeAttributes(e.Attributes),
+			})
+		}
+		ss.Spans = append(ss.Spans, encoded)
+	}
+	return request
+}
+
+// encodeStatusCode returns the otlp status code, the order of the codes differs from the otel codes
+func encodeStatusCode(code codes.Code) int {
+	switch code {
+	case codes.Ok:
+		return 1
+	case codes.Error:
+		return 2
+	}
+	return 0
+}
+
+// encodeAttributes returns the otlp attributes
+func encodeAttributes(attributes []attribute.KeyValue) []keyValue {
+	encoded := make([]keyValue, 0, len(attributes))
+	for _, kv := range attributes {
+		encoded = append(encoded, keyValue{Key: string(kv.Key), Value: encodeValue(kv.Value)})
+	}
+	return encoded
+}
+
+// encodeValue returns the otlp value of the attribute
+func encodeValue(value attribute.Value) anyValue {
+	switch value.Type() {
+	case attribute.BOOL:
+		v := value.AsBool()
+		return anyValue{BoolValue: &v}
+	case attribute.INT64:
+		v := strconv.FormatInt(value.AsInt64(), 10)
+		return anyValue{IntValue: &v}
+	case attribute.FLOAT64:
+		v := value.AsFloat64()
+		return anyValue{DoubleValue: &v}
+	case attribute.STRINGSLICE:
+		values := []anyValue{}
+		for _, s := range value.AsStringSlice() {
+			values = append(values, encodeValue(attribute.StringValue(s)))
+		}
+		return anyValue{ArrayValue: &arrayValue{Values: values}}
+	case attribute.BOOLSLICE, attribute.INT64SLICE, attribute.FLOAT64SLICE:
+		v := value.Emit()
+		return anyValue{StringValue: &v}
+	}
+	v := value.AsString()
+	return anyValue{StringValue: &v}
+}
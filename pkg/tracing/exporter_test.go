@@ -0,0 +1,246 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// useTestTracer replaces the tracer of the run with the one recording the spans, it is restored at the end of the test
+func useTestTracer(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(recorder),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "litmus-go"))),
+	)
+	previous := runTracer
+	runTracer = &tracer{
+		provider: provider,
+		tracer:   provider.Tracer(tracerName),
+		rootCtx:  context.Background(),
+		phaseCtx: context.Background(),
+		targets:  map[string]trace.Span{},
+	}
+	t.Cleanup(func() {
+		runTracer = previous
+	})
+	return recorder
+}
+
+// decodeSpans round-trips the spans through the otlp json encoding and returns them by the span name
+func decodeSpans(t *testing.T, spans []sdktrace.ReadOnlySpan) (exportRequest, map[string]span) {
+	body, err := json.Marshal(encodeSpans(spans))
+	require.NoError(t, err)
+	request := exportRequest{}
+	require.NoError(t, json.Unmarshal(body, &request))
+
+	decoded := map[string]span{}
+	for _, rs := range request.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, s := range ss.Spans {
+				decoded[s.Name] = s
+			}
+		}
+	}
+	return request, decoded
+}
+
+// getAttributes returns the string form of the otlp attributes
+func getAttributes(attributes []keyValue) map[string]interface{} {
+	values := map[string]interface{}{}
+	for _, kv := range attributes {
+		switch {
+		case kv.Value.StringValue != nil:
+			values[kv.Key] = *kv.Value.StringValue
+		case kv.Value.BoolValue != nil:
+			values[kv.Key] = *kv.Value.BoolValue
+		case kv.Value.IntValue != nil:
+			values[kv.Key] = "int:" + *kv.Value.IntValue
+		case kv.Value.DoubleValue != nil:
+			values[kv.Key] = *kv.Value.DoubleValue
+		case kv.Value.ArrayValue != nil:
+			array := []string{}
+			for _, v := range kv.Value.ArrayValue.Values {
+				array = append(array, *v.StringValue)
+			}
+			values[kv.Key] = array
+		}
+	}
+	return values
+}
+
+// assertHexID asserts that the id is the lowercase hex of the given size in bytes
+func assertHexID(t *testing.T, id string, size int) {
+	decoded, err := hex.DecodeString(id)
+	require.NoError(t, err, id)
+	assert.Len(t, decoded, size)
+	assert.NotEqual(t, make([]byte, size), decoded)
+}
+
+func TestEncodeSpans(t *testing.T) {
+	recorder := useTestTracer(t)
+
+	Initialise("Experiment", attribute.String("chaos.experiment", "pod-delete"))
+	StartPhase("ChaosInject")
+	probeSpan := StartSpan("Probe http-health", attribute.String("chaos.probe.type", "httpProbe"), attribute.Int("chaos.probe.attempt", 2),
+		attribute.Float64("chaos.probe.timeout", 1.5), attribute.StringSlice("chaos.probe.urls", []string{"http://nginx", "http://nginx-canary"}))
+	EndSpan(probeSpan, cerrors.Error{ErrorCode: cerrors.FailureTypeHttpProbe, Reason: "status code is 500"}, string(cerrors.FailureTypeHttpProbe))
+	passedSpan := StartSpan("Probe cmd-check")
+	passedSpan.SetStatus(codes.Ok, "")
+	EndSpan(passedSpan, nil, "")
+	RecordTargetStatus("nginx-7d8f9c-abcde", "pod", "injected")
+	RecordTargetStatus("nginx-7d8f9c-abcde", "pod", "reverted")
+	End(string(cerrors.ErrorTypeHelperPodFailed), attribute.String("chaos.fail_step", "helper pod is not found"))
+
+	request, spans := decodeSpans(t, recorder.Ended())
+	require.Len(t, request.ResourceSpans, 1)
+	assert.Equal(t, map[string]interface{}{"service.name": "litmus-go"}, getAttributes(request.ResourceSpans[0].Resource.Attributes))
+	require.Len(t, request.ResourceSpans[0].ScopeSpans, 1)
+	assert.Equal(t, tracerName, request.ResourceSpans[0].ScopeSpans[0].Scope.Name)
+	require.Len(t, spans, 5)
+
+	root, phase := spans["Experiment"], spans["ChaosInject"]
+	for name, s := range spans {
+		assertHexID(t, s.TraceID, 16)
+		assertHexID(t, s.SpanID, 8)
+		assert.Equal(t, root.TraceID, s.TraceID, name)
+		startTime, err := strconv.ParseInt(s.StartTimeUnixNano, 10, 64)
+		require.NoError(t, err)
+		endTime, err := strconv.ParseInt(s.EndTimeUnixNano, 10, 64)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, startTime, endTime, name)
+	}
+	assert.Equal(t, recorder.Ended()[0].SpanContext().TraceID().String(), root.TraceID)
+
+	// the parent ids follow the root, phase and probe/injection hierarchy
+	assert.Empty(t, root.ParentSpanID)
+	assert.Equal(t, root.SpanID, phase.ParentSpanID)
+	assert.Equal(t, phase.SpanID, spans["Probe http-health"].ParentSpanID)
+	assert.Equal(t, phase.SpanID, spans["Probe cmd-check"].ParentSpanID)
+	assert.Equal(t, phase.SpanID, spans["ChaosInjection pod/nginx-7d8f9c-abcde"].ParentSpanID)
+
+	// the error codes of the failures are recorded as the status and attributes
+	assert.Equal(t, status{Code: 2, Message: string(cerrors.ErrorTypeHelperPodFailed)}, root.Status)
+	assert.Equal(t, map[string]interface{}{
+		"chaos.experiment": "pod-delete",
+		"chaos.fail_step":  "helper pod is not found",
+		"chaos.error.code": string(cerrors.ErrorTypeHelperPodFailed),
+	}, getAttributes(root.Attributes))
+
+	failed := spans["Probe http-health"]
+	assert.Equal(t, status{Code: 2, Message: string(cerrors.FailureTypeHttpProbe)}, failed.Status)
+	assert.Equal(t, map[string]interface{}{
+		"chaos.probe.type":    "httpProbe",
+		"chaos.probe.attempt": "int:2",
+		"chaos.probe.timeout": 1.5,
+		"chaos.probe.urls":    []string{"http://nginx", "http://nginx-canary"},
+		"chaos.error.code":    string(cerrors.FailureTypeHttpProbe),
+	}, getAttributes(failed.Attributes))
+	require.Len(t, failed.Events, 1)
+	assert.Equal(t, "exception", failed.Events[0].Name)
+	assert.Contains(t, getAttributes(failed.Events[0].Attributes)["exception.message"], "status code is 500")
+
+	assert.Equal(t, status{Code: 1}, spans["Probe cmd-check"].Status)
+	assert.Equal(t, status{Code: 0}, phase.Status)
+	assert.Equal(t, map[string]interface{}{"chaos.phase": "ChaosInject"}, getAttributes(phase.Attributes))
+	assert.Equal(t, map[string]interface{}{
+		"chaos.target.name": "nginx-7d8f9c-abcde",
+		"chaos.target.kind": "pod",
+		"k8s.pod.name":      "nginx-7d8f9c-abcde",
+		"chaos.reverted":    true,
+	}, getAttributes(spans["ChaosInjection pod/nginx-7d8f9c-abcde"].Attributes))
+}
+
+func TestTraceParentPropagation(t *testing.T) {
+	experimentRecorder := useTestTracer(t)
+	Initialise("Experiment")
+	StartPhase("ChaosInject")
+	// the trace context of the phase is passed to the helper pod via TRACEPARENT env
+	traceParent := TraceParent()
+	require.NotEmpty(t, traceParent)
+	End("")
+
+	helperRecorder := useTestTracer(t)
+	t.Setenv(traceParentEnv, traceParent)
+	Initialise("Helper", attribute.String("chaos.helper", "network-chaos"))
+	StartPhase("ChaosInject")
+	End("")
+
+	_, experimentSpans := decodeSpans(t, experimentRecorder.Ended())
+	_, helperSpans := decodeSpans(t, helperRecorder.Ended())
+	require.Len(t, helperSpans, 2)
+	experimentPhase, helperRoot := experimentSpans["ChaosInject"], helperSpans["Helper"]
+	assert.Equal(t, experimentSpans["Experiment"].TraceID, helperRoot.TraceID)
+	assert.Equal(t, experimentSpans["Experiment"].TraceID, helperSpans["ChaosInject"].TraceID)
+	// the root span of the helper is the child of the phase span of the experiment
+	assert.Equal(t, experimentPhase.SpanID, helperRoot.ParentSpanID)
+	assert.Equal(t, helperRoot.SpanID, helperSpans["ChaosInject"].ParentSpanID)
+}
+
+func TestTraceParentWithoutTracing(t *testing.T) {
+	previous := runTracer
+	runTracer = &tracer{
+		tracer:   trace.NewNoopTracerProvider().Tracer(tracerName),
+		rootCtx:  context.Background(),
+		phaseCtx: context.Background(),
+		targets:  map[string]trace.Span{},
+	}
+	t.Cleanup(func() {
+		runTracer = previous
+	})
+
+	// the helper pods start a new trace, if the experiment isn't traced
+	assert.Empty(t, TraceParent())
+	recorder := useTestTracer(t)
+	t.Setenv(traceParentEnv, "")
+	Initialise("Helper")
+	End("")
+	_, spans := decodeSpans(t, recorder.Ended())
+	assertHexID(t, spans["Helper"].TraceID, 16)
+	assert.Empty(t, spans["Helper"].ParentSpanID)
+}
+
+func TestExportSpans(t *testing.T) {
+	var (
+		headers http.Header
+		body    []byte
+	)
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	recorder := useTestTracer(t)
+	Initialise("Experiment")
+	End("")
+
+	exporter := newOTLPExporter(server.URL+"/v1/traces", "authorization=Bearer a1b2c3, x-tenant = litmus,invalid")
+	require.NoError(t, exporter.ExportSpans(context.Background(), recorder.Ended()))
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "Bearer a1b2c3", headers.Get("Authorization"))
+	assert.Equal(t, "litmus", headers.Get("X-Tenant"))
+	request := exportRequest{}
+	require.NoError(t, json.Unmarshal(body, &request))
+	assert.Equal(t, "Experiment", request.ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+
+	statusCode = http.StatusBadRequest
+	assert.Error(t, exporter.ExportSpans(context.Background(), recorder.Ended()))
+}
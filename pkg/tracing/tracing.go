@@ -0,0 +1,237 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// tracerName is the instrumentation scope of the spans
+	tracerName = "github.com/figwood/litmus-go"
+	// traceParentEnv contains the w3c trace context of the parent span, it is set in the helper pods
+	traceParentEnv = "TRACEPARENT"
+)
+
+// phaseSpanNames contains the span names of the experiment phases
+var phaseSpanNames = map[string]string{
+	"PreChaos":    "PreChaosCheck",
+	"ChaosInject": "ChaosInject",
+	"PostChaos":   "PostChaosCheck",
+}
+
+// tracer contains the spans of the run, the spans are exported only if the otlp endpoint is provided
+type tracer struct {
+	mutex    sync.Mutex
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer
+	root     trace.Span
+	rootCtx  context.Context
+	phase    trace.Span
+	phaseCtx context.Context
+	targets  map[string]trace.Span
+	ended    bool
+}
+
+var runTracer = &tracer{
+	tracer:   trace.NewNoopTracerProvider().Tracer(tracerName),
+	rootCtx:  context.Background(),
+	phaseCtx: context.Background(),
+	targets:  map[string]trace.Span{},
+}
+
+// Initialise configures the tracing and starts the root span of the experiment or helper pod
+// the spans are exported via otlp/http, if OTEL_EXPORTER_OTLP_TRACES_ENDPOINT or OTEL_EXPORTER_OTLP_ENDPOINT env is provided
+// the root span is the child of the span provided via TRACEPARENT env, so that the helper pods join the trace of the experiment
+func Initialise(spanName string, attributes ...attribute.KeyValue) {
+	runTracer.mutex.Lock()
+	defer runTracer.mutex.Unlock()
+	if runTracer.root != nil {
+		return
+	}
+
+	if endpoint := getTracesEndpoint(); endpoint != "" {
+		runTracer.provider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(newOTLPExporter(endpoint, os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"))),
+			sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", getenv("OTEL_SERVICE_NAME", "litmus-go")))),
+		)
+		runTracer.tracer = runTracer.provider.Tracer(tracerName)
+		log.Infof("[Tracing]: Exporting the spans to %v", endpoint)
+	}
+
+	parentCtx := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{"traceparent": os.Getenv(traceParentEnv)})
+	runTracer.rootCtx, runTracer.root = runTracer.tracer.Start(parentCtx, spanName, trace.WithAttributes(attributes...))
+	runTracer.phaseCtx = runTracer.rootCtx
+}
+
+// SetAttributes sets the attributes of the root span
+func SetAttributes(attributes ...attribute.KeyValue) {
+	runTracer.mutex.Lock()
+	defer runTracer.mutex.Unlock()
+	if runTracer.root != nil {
+		runTracer.root.SetAttributes(attributes...)
+	}
+}
+
+// StartPhase ends the span of the previous phase and starts the span of the given phase
+func StartPhase(phase string) {
+	runTracer.mutex.Lock()
+	defer runTracer.mutex.Unlock()
+	if runTracer.ended {
+		return
+	}
+	if runTracer.phase != nil {
+		runTracer.phase.End()
+	}
+	name, ok := phaseSpanNames[phase]
+	if !ok {
+		name = phase
+	}
+	runTracer.phaseCtx, runTracer.phase = runTracer.tracer.Start(runTracer.rootCtx, name, trace.WithAttributes(attribute.String("chaos.phase", phase)))
+}
+
+// StartSpan starts the span as the child of the current phase
+// the caller should end the span, via EndSpan
+func StartSpan(name string, attributes ...attribute.KeyValue) trace.Span {
+	runTracer.mutex.Lock()
+	defer runTracer.mutex.Unlock()
+	_, span := runTracer.tracer.Start(runTracer.phaseCtx, name, trace.WithAttributes(attributes...))
+	return span
+}
+
+// EndSpan ends the span, it records the error and its error code, if any
+func EndSpan(span trace.Span, err error, errorCode string) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, errorCode)
+		span.SetAttributes(attribute.String("chaos.error.code", errorCode))
+	}
+	span.End()
+}
+
+// RecordTargetStatus starts the injection span of the target once the chaos is injected
+// and ends it once the chaos is reverted
+func RecordTargetStatus(target, kind, chaosStatus string) {
+	key := kind + "/" + target
+	switch strings.ToLower(chaosStatus) {
+	case "injected", "detached":
+		runTracer.mutex.Lock()
+		defer runTracer.mutex.Unlock()
+		if _, ok := runTracer.targets[key]; ok || runTracer.ended {
+			return
+		}
+		_, span := runTracer.tracer.Start(runTracer.phaseCtx, "ChaosInjection "+key, trace.WithAttributes(getTargetAttributes(target, kind)...))
+		runTracer.targets[key] = span
+	case "reverted", "re-attached":
+		runTracer.mutex.Lock()
+		defer runTracer.mutex.Unlock()
+		if span, ok := runTracer.targets[key]; ok {
+			span.SetAttributes(attribute.Bool("chaos.reverted", true))
+			span.End()
+			delete(runTracer.targets, key)
+		}
+	}
+}
+
+// getTargetAttributes returns the attributes of the target, it contains the pod or node name based on the kind
+func getTargetAttributes(target, kind string) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		attribute.String("chaos.target.name", target),
+		attribute.String("chaos.target.kind", kind),
+	}
+	switch strings.ToLower(kind) {
+	case "pod":
+		attributes = append(attributes, attribute.String("k8s.pod.name", target))
+	case "node":
+		attributes = append(attributes, attribute.String("k8s.node.name", target))
+	}
+	return attributes
+}
+
+// TraceParent returns the w3c trace context of the current phase, it is passed to the helper pods via TRACEPARENT env
+func TraceParent() string {
+	runTracer.mutex.Lock()
+	defer runTracer.mutex.Unlock()
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(runTracer.phaseCtx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// End ends all the open spans along with the root span and exports them
+// the injection spans of the targets which are not reverted are marked as not reverted
+func End(errorCode string, attributes ...attribute.KeyValue) {
+	runTracer.mutex.Lock()
+	defer runTracer.mutex.Unlock()
+	if runTracer.ended || runTracer.root == nil {
+		return
+	}
+	runTracer.ended = true
+
+	for key, span := range runTracer.targets {
+		span.SetAttributes(attribute.Bool("chaos.reverted", false))
+		span.End()
+		delete(runTracer.targets, key)
+	}
+	if runTracer.phase != nil {
+		runTracer.phase.End()
+	}
+	runTracer.root.SetAttributes(attributes...)
+	if errorCode != "" {
+		runTracer.root.SetStatus(codes.Error, errorCode)
+		runTracer.root.SetAttributes(attribute.String("chaos.error.code", errorCode))
+	}
+	runTracer.root.End()
+
+	if runTracer.provider != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := runTracer.provider.ForceFlush(ctx); err != nil {
+			log.Errorf("[Tracing]: Unable to export the spans, err: %v", err)
+		}
+	}
+}
+
+// Shutdown ends the open spans and shuts down the exporter
+func Shutdown() {
+	End("")
+	runTracer.mutex.Lock()
+	defer runTracer.mutex.Unlock()
+	if runTracer.provider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := runTracer.provider.Shutdown(ctx); err != nil {
+		log.Errorf("[Tracing]: Unable to shutdown the tracer, err: %v", err)
+	}
+	runTracer.provider = nil
+}
+
+// getTracesEndpoint returns the otlp/http traces endpoint
+func getTracesEndpoint() string {
+	if endpoint := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")); endpoint != "" {
+		return endpoint
+	}
+	if endpoint := strings.TrimSpace(os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+	}
+	return ""
+}
+
+// getenv returns the value of the env, it returns the default value if the env is not set
+func getenv(key, defaultValue string) string {
+	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+		return value
+	}
+	return defaultValue
+}
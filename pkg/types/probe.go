@@ -0,0 +1,486 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ProbeInputs contains the probe inputs which are not part of the chaos-operator probe schema
+// these inputs are read directly from the probe spec of the chaosengine
+type ProbeInputs struct {
+	// inputs needed for the grpc probe
+	GRPCProbeInputs *GRPCProbeInputs `json:"grpcProbe/inputs,omitempty"`
+	// inputs needed for the socket probe
+	SocketProbeInputs *SocketProbeInputs `json:"socketProbe/inputs,omitempty"`
+	// inputs needed for the dns probe
+	DNSProbeInputs *DNSProbeInputs `json:"dnsProbe/inputs,omitempty"`
+	// additional inputs for the http probe
+	HTTPProbeInputs *HTTPProbeInputs `json:"httpProbe/inputs,omitempty"`
+	// additional inputs for the prom probe
+	PromProbeInputs *PromProbeInputs `json:"promProbe/inputs,omitempty"`
+	// additional inputs for the k8s probe
+	K8sProbeInputs *K8sProbeInputs `json:"k8sProbe/inputs,omitempty"`
+	// inputs needed for the log probe
+	LogProbeInputs *LogProbeInputs `json:"logProbe/inputs,omitempty"`
+	// inputs needed for the event probe
+	EventProbeInputs *EventProbeInputs `json:"eventProbe/inputs,omitempty"`
+	// inputs needed for the restart probe
+	RestartProbeInputs *RestartProbeInputs `json:"restartProbe/inputs,omitempty"`
+	// additional inputs for the cmd probe
+	CmdProbeInputs *CmdProbeInputs `json:"cmdProbe/inputs,omitempty"`
+	// inputs needed for the sql probe
+	SQLProbeInputs *SQLProbeInputs `json:"sqlProbe/inputs,omitempty"`
+	// named outputs extracted from the output of the cmd, http, prom and sql probes
+	Outputs []ProbeOutput `json:"outputs,omitempty"`
+	// Halt reverts the chaos immediately, if the continuous or onchaos probe breaches
+	// the experiment is marked as stopped with the breaching probe
+	Halt bool `json:"halt,omitempty"`
+}
+
+// GRPCProbeInputs contains all the inputs required for grpc probe
+type GRPCProbeInputs struct {
+	// Host contains the address(host:port) of the grpc server
+	Host string `json:"host"`
+	// Service contains the service name sent inside the health check request
+	// it will check the overall health of the server if not provided
+	Service string `json:"service,omitempty"`
+	// Method contains the unary method which need to be invoked instead of the health check
+	Method *GRPCMethod `json:"method,omitempty"`
+	// Metadata contains the metadata sent with each request
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// TLS contains the tls details for the grpc connection
+	// it will use the plaintext connection if not provided
+	TLS *TLSInputs `json:"tls,omitempty"`
+}
+
+// GRPCMethod contains the details of the unary method invoked by the grpc probe
+type GRPCMethod struct {
+	// Name contains the fully qualified name of method, e.g. package.Service/Method
+	Name string `json:"name"`
+	// Request contains the request message in the json format
+	Request string `json:"request,omitempty"`
+	// Comparator check for the correctness of the json response
+	Comparator *v1alpha1.ComparatorInfo `json:"comparator,omitempty"`
+}
+
+// SocketProbeInputs contains all the inputs required for socket probe
+type SocketProbeInputs struct {
+	// Protocol contains the transport protocol of the endpoints
+	// it can be tcp or udp, default value is tcp
+	Protocol string `json:"protocol,omitempty"`
+	// Endpoints contains the list of addresses(host:port) need to be probed
+	Endpoints []string `json:"endpoints"`
+	// Send contains the payload sent after connecting to the endpoint
+	// it is required for the udp protocol
+	Send string `json:"send,omitempty"`
+	// Expect contains the bytes which should be present inside the banner/response
+	Expect string `json:"expect,omitempty"`
+	// Latency check for the connect(tcp) or round trip(udp) latency in milliseconds
+	Latency *v1alpha1.ComparatorInfo `json:"latency,omitempty"`
+}
+
+// DNSProbeInputs contains all the inputs required for dns probe
+type DNSProbeInputs struct {
+	// Names contains the list of names need to be resolved
+	Names []string `json:"names"`
+	// QueryType contains the type of the dns query
+	// it can be A or AAAA, default value is A
+	QueryType string `json:"queryType,omitempty"`
+	// Resolver contains the address(host:port) of the dns server
+	// it will use the nameserver from resolv.conf(cluster dns) if not provided
+	Resolver string `json:"resolver,omitempty"`
+	// Transport contains the transport protocol used for the query
+	// it can be udp or tcp, default value is udp
+	Transport string `json:"transport,omitempty"`
+	// Rcode contains the expected response code, e.g. NOERROR, NXDOMAIN, SERVFAIL
+	// default value is NOERROR
+	Rcode string `json:"rcode,omitempty"`
+	// Records check for the returned A/AAAA records
+	// records are sorted and joined with space before the comparison
+	Records *v1alpha1.ComparatorInfo `json:"records,omitempty"`
+	// Latency check for the lookup latency in milliseconds
+	Latency *v1alpha1.ComparatorInfo `json:"latency,omitempty"`
+	// Target contains the pod inside whose network namespace the names are resolved
+	Target *DNSProbeTarget `json:"target,omitempty"`
+}
+
+// DNSProbeTarget contains the details of the pod used as the network namespace for the dns probe
+// the experiment pod should run with hostPID and privileged mode to enter the network namespace of the target
+type DNSProbeTarget struct {
+	// PodName contains the name of the target pod
+	PodName string `json:"podName"`
+	// Namespace contains the namespace of the target pod
+	Namespace string `json:"namespace"`
+	// Container contains the name of the target container
+	// it will use the first container of the pod if not provided
+	Container string `json:"container,omitempty"`
+}
+
+// HTTPProbeInputs contains the additional inputs for the http probe
+// these inputs extends the get/post methods of the httpProbe/inputs
+type HTTPProbeInputs struct {
+	// Method contains the http method which overrides the get/post method
+	Method *HTTPMethod `json:"method,omitempty"`
+	// Headers contains the headers sent with each request
+	Headers []HTTPHeader `json:"headers,omitempty"`
+	// ResponseBody contains the assertions on the response body
+	ResponseBody []HTTPBodyCheck `json:"responseBody,omitempty"`
+	// ResponseHeaders contains the assertions on the response headers
+	ResponseHeaders []HTTPHeaderCheck `json:"responseHeaders,omitempty"`
+	// SLO contains the latency and error rate thresholds for the continuous mode
+	SLO *HTTPSLO `json:"slo,omitempty"`
+	// TLS contains the ca bundle, client certificates and server name for the https requests
+	// the files can be mounted from the secrets via the experiment pod volumes
+	TLS *TLSInputs `json:"tls,omitempty"`
+}
+
+// HTTPMethod contains the details of the http method
+type HTTPMethod struct {
+	// Name contains the name of http method, e.g. GET, POST, PUT, PATCH, DELETE, HEAD
+	Name string `json:"name"`
+	// ContentType contains content type for http body data
+	ContentType string `json:"contentType,omitempty"`
+	// Body contains http body for the request
+	Body string `json:"body,omitempty"`
+	// BodyPath contains filePath, which contains http body
+	BodyPath string `json:"bodyPath,omitempty"`
+	// Criteria for matching the response code
+	Criteria string `json:"criteria"`
+	// ResponseCode contains the expected response code
+	ResponseCode string `json:"responseCode"`
+}
+
+// HTTPHeader contains the details of the request header
+type HTTPHeader struct {
+	// Name contains the name of the header
+	Name string `json:"name"`
+	// Value contains the value of the header
+	Value string `json:"value,omitempty"`
+	// ValueFrom contains the source of the header value, it overrides the value
+	ValueFrom *HTTPHeaderSource `json:"valueFrom,omitempty"`
+}
+
+// HTTPHeaderSource contains the source of the header value
+type HTTPHeaderSource struct {
+	// SecretKeyRef selects a key of the secret present inside the chaos namespace
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// HTTPBodyCheck contains the assertion on the response body
+type HTTPBodyCheck struct {
+	// JSONPath contains the jsonpath expression to extract the value from the json body
+	// it will use the whole body if not provided
+	JSONPath string `json:"jsonPath,omitempty"`
+	// Comparator check for the correctness of the extracted value
+	// regex can be checked with the matches and notMatches criteria
+	Comparator v1alpha1.ComparatorInfo `json:"comparator"`
+}
+
+// HTTPHeaderCheck contains the assertion on the response header
+type HTTPHeaderCheck struct {
+	// Name contains the name of the header
+	Name string `json:"name"`
+	// Comparator check for the correctness of the header value
+	Comparator v1alpha1.ComparatorInfo `json:"comparator"`
+}
+
+// HTTPSLO contains the thresholds evaluated over all the requests of the continuous http probe
+// the probe doesn't fail on the first failed request, it is evaluated at the end of the chaos
+type HTTPSLO struct {
+	// P50 contains the maximum 50th percentile latency in milliseconds
+	P50 string `json:"p50,omitempty"`
+	// P95 contains the maximum 95th percentile latency in milliseconds
+	P95 string `json:"p95,omitempty"`
+	// P99 contains the maximum 99th percentile latency in milliseconds
+	P99 string `json:"p99,omitempty"`
+	// ErrorBudget contains the maximum percentage of the failed requests
+	// it will not check the error rate if not provided
+	ErrorBudget string `json:"errorBudget,omitempty"`
+}
+
+// PromProbeInputs contains the additional inputs for the prom probe
+type PromProbeInputs struct {
+	// Range contains the window of the range query
+	// it will use the instant query if not provided
+	Range *PromRange `json:"range,omitempty"`
+	// Auth contains the authentication details of the prometheus endpoint
+	Auth *PromAuth `json:"auth,omitempty"`
+	// Headers contains the headers sent with each query, e.g. X-Scope-OrgID for the multi-tenant backends
+	Headers []HTTPHeader `json:"headers,omitempty"`
+	// TLS contains the ca bundle, client certificates and server name for the https endpoint
+	TLS *TLSInputs `json:"tls,omitempty"`
+	// Baseline captures the value in the prechaos phase and compares the later values relative to it
+	Baseline *PromBaseline `json:"baseline,omitempty"`
+}
+
+// PromBaseline contains the details of the baseline comparison
+// the comparator of the probe is applied on the relative value instead of the observed value
+type PromBaseline struct {
+	// Relation contains the relation between the observed and baseline value
+	// it can be ratio(observed/baseline), delta(observed-baseline) or percent(percent change from baseline)
+	Relation string `json:"relation"`
+}
+
+// PromRange contains the details of the range query
+type PromRange struct {
+	// Window contains the duration of the range ending at the query time, e.g. 5m
+	Window string `json:"window"`
+	// Step contains the query resolution step, default value is 15s
+	Step string `json:"step,omitempty"`
+	// Aggregation contains the function to reduce the samples to a single value
+	// it can be last, avg, min, max or sum, default value is last
+	Aggregation string `json:"aggregation,omitempty"`
+}
+
+// PromAuth contains the authentication details of the prometheus endpoint
+// the credentials are read from the files mounted inside the experiment pod
+type PromAuth struct {
+	// BearerTokenPath contains the path of the bearer token
+	BearerTokenPath string `json:"bearerTokenPath,omitempty"`
+	// Username contains the username for the basic auth
+	Username string `json:"username,omitempty"`
+	// PasswordPath contains the path of the password for the basic auth
+	PasswordPath string `json:"passwordPath,omitempty"`
+}
+
+// LogProbeInputs contains all the inputs required for log probe
+type LogProbeInputs struct {
+	// Namespace contains the namespace of the pods
+	Namespace string `json:"namespace,omitempty"`
+	// LabelSelector contains the labels of the pods whose logs are scanned
+	// it will scan the pods of the chaos targets(appinfo) if not provided
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// Container contains the name of the container
+	// it will scan all the containers of the pods if not provided
+	Container string `json:"container,omitempty"`
+	// Patterns contains the regex patterns matched against each log line
+	Patterns []LogPattern `json:"patterns"`
+	// MaxArtifacts contains the maximum number of the matched lines recorded per pattern
+	// default value is 10
+	MaxArtifacts int `json:"maxArtifacts,omitempty"`
+}
+
+// LogPattern contains the regex pattern along with the threshold of the matched lines
+type LogPattern struct {
+	// Regex contains the regular expression matched against each log line
+	Regex string `json:"regex"`
+	// Count check for the number of the matched lines, the value is compared as int
+	// default value is {criteria: "<=", value: "0"}, i.e. the pattern should not appear
+	Count *v1alpha1.ComparatorInfo `json:"count,omitempty"`
+}
+
+// EventProbeInputs contains all the inputs required for event probe
+type EventProbeInputs struct {
+	// Namespaces contains the namespaces of the events
+	// it will use the namespaces of the chaos targets(appinfo) if not provided
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Kinds contains the kinds of the involved objects, e.g. Pod, Node
+	// it will match all the kinds if not provided
+	Kinds []string `json:"kinds,omitempty"`
+	// Reasons contains the reasons of the events, e.g. FailedScheduling, BackOff, OOMKilling, FailedMount
+	// it will match all the reasons if not provided
+	Reasons []string `json:"reasons,omitempty"`
+	// Type contains the type of the events, it can be Warning or Normal
+	// default value is Warning
+	Type string `json:"type,omitempty"`
+	// Count check for the number of the matched events, the value is compared as int
+	// default value is {criteria: "<=", value: "0"}, i.e. no matching event should occur
+	Count *v1alpha1.ComparatorInfo `json:"count,omitempty"`
+	// MaxArtifacts contains the maximum number of the matched events recorded
+	// default value is 10
+	MaxArtifacts int `json:"maxArtifacts,omitempty"`
+}
+
+// K8sProbeInputs contains the additional inputs for the k8s probe
+type K8sProbeInputs struct {
+	// FieldChecks contains the assertions on the fields of the resources
+	// it is evaluated on all the matching resources for the present operation
+	FieldChecks []K8sFieldCheck `json:"fieldChecks,omitempty"`
+	// PatchType contains the type of the patch applied by the patch operation
+	// it can be merge(json merge patch) or strategic(strategic merge patch), default value is merge
+	PatchType string `json:"patchType,omitempty"`
+}
+
+// K8sFieldCheck contains the assertion on a field of the resource
+type K8sFieldCheck struct {
+	// JSONPath contains the jsonpath expression to extract the field value, e.g. .status.readyReplicas
+	// or .status.conditions[?(@.type=="Ready")].status for the status of a condition
+	JSONPath string `json:"jsonPath"`
+	// Comparator check for the correctness of the field value
+	Comparator v1alpha1.ComparatorInfo `json:"comparator"`
+}
+
+// K8sProbeResource contains the details of the resource created or modified by the k8s probe
+// these resources are deleted or restored at the end of the experiment
+type K8sProbeResource struct {
+	GVR       schema.GroupVersionResource
+	Namespace string
+	Name      string
+	// Original contains the resource before its first modification, it is nil for the created resources
+	Original *unstructured.Unstructured
+	// Modified contains the resource after its last modification, the fields changed by the probe are reverted
+	Modified *unstructured.Unstructured
+}
+
+// TLSInputs contains the tls details for the probe connections
+type TLSInputs struct {
+	// InsecureSkipVerify flag to skip certificate checks
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// CACertPath contains the path of the ca bundle
+	CACertPath string `json:"caCertPath,omitempty"`
+	// ClientCertPath contains the path of the client certificate
+	ClientCertPath string `json:"clientCertPath,omitempty"`
+	// ClientKeyPath contains the path of the client key
+	ClientKeyPath string `json:"clientKeyPath,omitempty"`
+	// ServerName overrides the server name used for the certificate verification and SNI
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// rawProbe contains the name and extended inputs of the probe defined inside chaosengine
+type rawProbe struct {
+	Name string `json:"name"`
+	ProbeInputs
+}
+
+// initializeProbeInputs derives the extended inputs of all the probes from the chaosengine
+// the typed chaosengine drops the unknown fields, so it reads the chaosengine via dynamic client
+// the chaosengine crd should preserve the unknown fields of the probes, see deploy/crds/chaosengine_crd.yaml
+func initializeProbeInputs(chaosDetails *ChaosDetails, clients clients.ClientSets, chaosresult *ResultDetails) error {
+	gvr := schema.GroupVersionResource{
+		Group:    "litmuschaos.io",
+		Version:  "v1alpha1",
+		Resource: "chaosengines",
+	}
+
+	engine, err := clients.DynamicClient.Resource(gvr).Namespace(chaosDetails.ChaosNamespace).Get(context.Background(), chaosDetails.EngineName, v1.GetOptions{})
+	if err != nil {
+		return cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: err.Error(), Target: fmt.Sprintf("{engineName: %s, engineNs: %s}", chaosDetails.EngineName, chaosDetails.ChaosNamespace)}
+	}
+
+	probeInputs, err := parseProbeInputs(engine.Object, chaosDetails)
+	if err != nil {
+		return err
+	}
+	for index := range chaosresult.ProbeDetails {
+		if inputs, ok := probeInputs[chaosresult.ProbeDetails[index].Name]; ok {
+			chaosresult.ProbeDetails[index].Inputs = inputs
+		}
+	}
+	return nil
+}
+
+// parseProbeInputs returns the extended inputs of the probes of the experiment, keyed by the probe name
+func parseProbeInputs(engine map[string]interface{}, chaosDetails *ChaosDetails) (map[string]ProbeInputs, error) {
+	experiments, _, err := unstructured.NestedSlice(engine, "spec", "experiments")
+	if err != nil {
+		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to parse the experiments, err: %v", err), Target: fmt.Sprintf("{engineName: %s, engineNs: %s}", chaosDetails.EngineName, chaosDetails.ChaosNamespace)}
+	}
+
+	probeInputs := map[string]ProbeInputs{}
+	for _, exp := range experiments {
+		experiment, ok := exp.(map[string]interface{})
+		if !ok || experiment["name"] != chaosDetails.ExperimentName {
+			continue
+		}
+		probes, _, err := unstructured.NestedSlice(experiment, "spec", "probe")
+		if err != nil {
+			return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to parse the probes, err: %v", err), Target: fmt.Sprintf("{engineName: %s, engineNs: %s}", chaosDetails.EngineName, chaosDetails.ChaosNamespace)}
+		}
+		for _, p := range probes {
+			data, err := json.Marshal(p)
+			if err != nil {
+				return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to parse the probe inputs, err: %v", err)}
+			}
+			probe := rawProbe{}
+			if err := json.Unmarshal(data, &probe); err != nil {
+				return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeGeneric, Reason: fmt.Sprintf("unable to parse the probe inputs, err: %v", err), Target: fmt.Sprintf("{probeName: %s}", probe.Name)}
+			}
+			probeInputs[probe.Name] = probe.ProbeInputs
+		}
+	}
+	return probeInputs, nil
+}
+
+// RestartProbeInputs contains all the inputs required for restart probe
+type RestartProbeInputs struct {
+	// Namespaces contains the namespaces of the pods
+	// it will use the namespaces of the chaos targets(appinfo) if not provided
+	Namespaces []string `json:"namespaces,omitempty"`
+	// LabelSelector contains the labels of the pods, it will check all the pods of the namespaces if not provided
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// MaxArtifacts contains the maximum number of the restarted containers recorded
+	// default value is 10
+	MaxArtifacts int `json:"maxArtifacts,omitempty"`
+}
+
+// SQLProbeInputs contains all the inputs required for sql probe
+type SQLProbeInputs struct {
+	// Driver contains the database driver, it can be postgres or mysql
+	Driver string `json:"driver"`
+	// DSN contains the plain data source name of the database, e.g. for the local runs
+	// the dsnFrom should be used for the credentials, it is ignored if the dsn is provided
+	DSN string `json:"dsn,omitempty"`
+	// DSNFrom contains the source of the data source name(dsn) of the database
+	DSNFrom SQLDSNSource `json:"dsnFrom,omitempty"`
+	// Query contains the query which returns a single scalar value
+	Query string `json:"query"`
+	// Comparator check for the correctness of the scalar value
+	Comparator v1alpha1.ComparatorInfo `json:"comparator"`
+}
+
+// SQLDSNSource contains the source of the data source name of the database
+type SQLDSNSource struct {
+	// SecretKeyRef selects a key of the secret present inside the chaos namespace
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// ContainerSnapshot contains the state of the container captured by the restart probe
+type ContainerSnapshot struct {
+	RestartCount int32
+	Terminated   bool
+}
+
+// CmdProbeInputs contains the inputs required for cmd probe
+// these inputs extends the cmdProbe/inputs of the chaos-operator probe schema
+type CmdProbeInputs struct {
+	// Exec runs the command inside the container of the target pods, instead of the experiment pod or the source pod
+	Exec *CmdProbeExec `json:"exec,omitempty"`
+}
+
+// CmdProbeExec contains the details of the pods in which the command is executed
+type CmdProbeExec struct {
+	// Namespace contains the namespace of the pods
+	// it will use the namespaces of the chaos targets(appinfo) if not provided
+	Namespace string `json:"namespace,omitempty"`
+	// LabelSelector contains the labels of the pods
+	// it will use the chaos targets(appinfo) if not provided
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// Container contains the name of the container, it will use the first container of the pod if not provided
+	Container string `json:"container,omitempty"`
+	// Policy defines the pods in which the command is executed, supported values: all, any, one
+	// all: the command should pass in all the pods, any: the command should pass in at least one pod
+	// one: the command is executed in one of the running pods
+	// default value is all
+	Policy string `json:"policy,omitempty"`
+}
+
+// ProbeOutput contains the details of the named output extracted from the output of the probe
+// the output can be referenced by the later probes as {{ .probe.<probe-name>.<output-name> }}
+type ProbeOutput struct {
+	// Name contains the name of the output
+	Name string `json:"name"`
+	// JSONPath contains the jsonpath expression to extract the output from the json output, e.g. {.data.token}
+	JSONPath string `json:"jsonPath,omitempty"`
+	// Regex contains the regular expression to extract the output, it extracts the first capture group if present
+	// otherwise the complete match. the complete output is registered, if both jsonPath and regex are not provided
+	Regex string `json:"regex,omitempty"`
+}
@@ -0,0 +1,272 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+// chaosEngine contains the probes of all the types, along with the inputs which are not part of the typed probe schema
+const chaosEngine = `
+apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosEngine
+metadata:
+  name: nginx-chaos
+  namespace: litmus
+spec:
+  engineState: active
+  chaosServiceAccount: pod-delete-sa
+  experiments:
+  - name: pod-delete
+    spec:
+      probe:
+      - name: grpc-health
+        type: grpcProbe
+        mode: Continuous
+        grpcProbe/inputs:
+          host: nginx.default.svc:50051
+          service: nginx
+        runProperties:
+          probeTimeout: 5s
+          interval: 2s
+      - name: http-put
+        type: httpProbe
+        mode: Edge
+        halt: true
+        httpProbe/inputs:
+          url: http://nginx.default.svc
+          method:
+            name: PUT
+            criteria: ==
+            responseCode: "200"
+          headers:
+          - name: Authorization
+            valueFrom:
+              secretKeyRef:
+                name: nginx-token
+                key: token
+          slo:
+            p95: "500"
+            errorBudget: "1"
+        outputs:
+        - name: version
+          jsonPath: '{.version}'
+        runProperties:
+          probeTimeout: 5s
+          interval: 2s
+      - name: prom-baseline
+        type: promProbe
+        mode: Edge
+        promProbe/inputs:
+          endpoint: http://prometheus:9090
+          query: sum(rate(http_requests_total[1m]))
+          comparator:
+            type: float
+            criteria: <=
+            value: "1.2"
+          baseline:
+            relation: ratio
+          range:
+            window: 5m
+        runProperties:
+          probeTimeout: 5s
+          interval: 2s
+      - name: k8s-patch
+        type: k8sProbe
+        mode: SOT
+        k8sProbe/inputs:
+          version: v1
+          resource: configmaps
+          namespace: default
+          resourceNames: nginx
+          operation: patch
+          patchType: strategic
+          fieldChecks:
+          - jsonPath: .data.mode
+            comparator:
+              type: string
+              criteria: equal
+              value: chaos
+        data: '{"data":{"mode":"chaos"}}'
+        runProperties:
+          probeTimeout: 5s
+          interval: 2s
+      - name: cmd-version
+        type: cmdProbe
+        mode: EOT
+        cmdProbe/inputs:
+          command: nginx -v
+          comparator:
+            type: semver
+            criteria: '>='
+            value: 1.21.0
+          exec:
+            labelSelector: app=nginx
+            policy: any
+        runProperties:
+          probeTimeout: 5s
+          interval: 2s
+      - name: sql-orders
+        type: sqlProbe
+        mode: EOT
+        sqlProbe/inputs:
+          driver: postgres
+          dsnFrom:
+            secretKeyRef:
+              name: orders-db
+              key: dsn
+          query: select count(*) from orders
+          comparator:
+            type: int
+            criteria: '>'
+            value: "0"
+        runProperties:
+          probeTimeout: 5s
+          interval: 2s
+      - name: log-errors
+        type: logProbe
+        mode: OnChaos
+        logProbe/inputs:
+          labelSelector: app=nginx
+          patterns:
+          - regex: panic
+        runProperties:
+          probeTimeout: 5s
+          interval: 2s
+`
+
+// prune drops the fields which are not part of the structural schema, the same way as the api server
+// it also records the violations of the required fields and string patterns
+func prune(value interface{}, schema map[string]interface{}, path string, violations *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		additionalProperties, _ := schema["additionalProperties"].(map[string]interface{})
+		preserveUnknownFields, _ := schema["x-kubernetes-preserve-unknown-fields"].(bool)
+		for key, field := range v {
+			switch fieldSchema, ok := properties[key].(map[string]interface{}); {
+			case ok:
+				prune(field, fieldSchema, path+"."+key, violations)
+			case additionalProperties != nil:
+				prune(field, additionalProperties, path+"."+key, violations)
+			case !preserveUnknownFields:
+				delete(v, key)
+			}
+		}
+		required, _ := schema["required"].([]interface{})
+		for _, key := range required {
+			if _, ok := v[key.(string)]; !ok {
+				*violations = append(*violations, fmt.Sprintf("%s.%s: required field is missing", path, key))
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for index, item := range v {
+				prune(item, items, fmt.Sprintf("%s[%d]", path, index), violations)
+			}
+		}
+	case string:
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(v) {
+			*violations = append(*violations, fmt.Sprintf("%s: '%s' should match '%s'", path, v, pattern))
+		}
+	}
+}
+
+// getChaosEngineSchema returns the openapi schema of the chaosengine crd
+func getChaosEngineSchema(t *testing.T) map[string]interface{} {
+	data, err := os.ReadFile("../../deploy/crds/chaosengine_crd.yaml")
+	require.NoError(t, err)
+	crd := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(data, &crd))
+
+	versions := crd["spec"].(map[string]interface{})["versions"].([]interface{})
+	return versions[0].(map[string]interface{})["schema"].(map[string]interface{})["openAPIV3Schema"].(map[string]interface{})
+}
+
+func TestPruneDropsUnknownFields(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{"type": "string", "pattern": "^[a-z]+$"},
+		},
+	}
+	object := map[string]interface{}{"name": "Nginx", "unknown": "value"}
+	var violations []string
+	prune(object, schema, "spec", &violations)
+
+	assert.Equal(t, map[string]interface{}{"name": "Nginx"}, object)
+	assert.Equal(t, []string{"spec.name: 'Nginx' should match '^[a-z]+$'"}, violations)
+}
+
+func TestParseProbeInputsFromPrunedChaosEngine(t *testing.T) {
+	engine := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(chaosEngine), &engine))
+
+	var violations []string
+	prune(engine, getChaosEngineSchema(t), "chaosengine", &violations)
+	require.Empty(t, violations)
+
+	// the typed probe attributes should be intact after the pruning
+	data, err := json.Marshal(engine)
+	require.NoError(t, err)
+	typedEngine := v1alpha1.ChaosEngine{}
+	require.NoError(t, json.Unmarshal(data, &typedEngine))
+	probes := typedEngine.Spec.Experiments[0].Spec.Probe
+	require.Len(t, probes, 7)
+	assert.Equal(t, "grpcProbe", probes[0].Type)
+	assert.Equal(t, "http://nginx.default.svc", probes[1].HTTPProbeInputs.URL)
+	assert.Equal(t, "patch", probes[3].K8sProbeInputs.Operation)
+	assert.Equal(t, "semver", probes[4].CmdProbeInputs.Comparator.Type)
+
+	inputs, err := parseProbeInputs(engine, &ChaosDetails{ExperimentName: "pod-delete", EngineName: "nginx-chaos", ChaosNamespace: "litmus"})
+	require.NoError(t, err)
+	require.Len(t, inputs, 7)
+
+	require.NotNil(t, inputs["grpc-health"].GRPCProbeInputs)
+	assert.Equal(t, "nginx.default.svc:50051", inputs["grpc-health"].GRPCProbeInputs.Host)
+
+	http := inputs["http-put"]
+	assert.True(t, http.Halt)
+	require.NotNil(t, http.HTTPProbeInputs)
+	require.NotNil(t, http.HTTPProbeInputs.Method)
+	assert.Equal(t, "PUT", http.HTTPProbeInputs.Method.Name)
+	require.Len(t, http.HTTPProbeInputs.Headers, 1)
+	assert.Equal(t, "nginx-token", http.HTTPProbeInputs.Headers[0].ValueFrom.SecretKeyRef.Name)
+	require.NotNil(t, http.HTTPProbeInputs.SLO)
+	assert.Equal(t, "500", http.HTTPProbeInputs.SLO.P95)
+	assert.Equal(t, []ProbeOutput{{Name: "version", JSONPath: "{.version}"}}, http.Outputs)
+
+	prom := inputs["prom-baseline"].PromProbeInputs
+	require.NotNil(t, prom)
+	require.NotNil(t, prom.Baseline)
+	assert.Equal(t, "ratio", prom.Baseline.Relation)
+	require.NotNil(t, prom.Range)
+	assert.Equal(t, "5m", prom.Range.Window)
+
+	k8s := inputs["k8s-patch"].K8sProbeInputs
+	require.NotNil(t, k8s)
+	assert.Equal(t, "strategic", k8s.PatchType)
+	require.Len(t, k8s.FieldChecks, 1)
+	assert.Equal(t, ".data.mode", k8s.FieldChecks[0].JSONPath)
+
+	cmd := inputs["cmd-version"].CmdProbeInputs
+	require.NotNil(t, cmd)
+	require.NotNil(t, cmd.Exec)
+	assert.Equal(t, "any", cmd.Exec.Policy)
+
+	sql := inputs["sql-orders"].SQLProbeInputs
+	require.NotNil(t, sql)
+	assert.Equal(t, "select count(*) from orders", sql.Query)
+	assert.Equal(t, "orders-db", sql.DSNFrom.SecretKeyRef.Name)
+
+	log := inputs["log-errors"].LogProbeInputs
+	require.NotNil(t, log)
+	assert.Equal(t, []LogPattern{{Regex: "panic"}}, log.Patterns)
+}
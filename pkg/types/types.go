@@ -4,15 +4,23 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/figwood/litmus-go/pkg/cerrors"
 	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/log"
+	"github.com/figwood/litmus-go/pkg/metrics"
+	"github.com/figwood/litmus-go/pkg/notify"
+	"github.com/figwood/litmus-go/pkg/tracing"
 	"github.com/figwood/litmus-go/pkg/utils/retry"
 	"github.com/figwood/litmus-go/pkg/utils/stringutils"
 	"github.com/palantir/stacktrace"
+	"go.opentelemetry.io/otel/attribute"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
@@ -66,6 +74,9 @@ type ResultDetails struct {
 	ProbeDetails     []*ProbeDetails
 	PassedProbeCount int
 	ProbeArtifacts   map[string]ProbeArtifact
+	// FailStep and ErrorCode contains the root cause of the unsuccessful run
+	FailStep  string
+	ErrorCode cerrors.ErrorType
 }
 
 // ProbeArtifact contains the probe artifacts
@@ -76,6 +87,8 @@ type ProbeArtifact struct {
 // RegisterDetails contains the output of the corresponding probe
 type RegisterDetails struct {
 	Register string
+	// Outputs contains the named outputs extracted from the output of the probe
+	Outputs map[string]string
 }
 
 // ProbeDetails is for collecting all the probe details
@@ -91,6 +104,15 @@ type ProbeDetails struct {
 	RunCount               int
 	Stopped                bool
 	Timeouts               ProbeTimeouts
+	Inputs                 ProbeInputs
+	// Baseline contains the value captured in the prechaos phase, used by the relative comparisons
+	Baseline string
+	// K8sResources contains the resources created or modified by the k8s probe
+	K8sResources []K8sProbeResource
+	// Artifacts contains the supporting details collected by the probe, e.g. the matched log lines
+	Artifacts []string
+	// Containers contains the state of the containers captured in the prechaos phase by the restart probe, keyed by <pod-uid>/<container-name>
+	Containers map[string]ContainerSnapshot
 }
 
 type ProbeTimeouts struct {
@@ -135,6 +157,20 @@ type ChaosDetails struct {
 	Phase                ExperimentPhase
 	ProbeContext         ProbeContext
 	SideCar              []SideCar
+	// PhaseTimings contains the start time of all the phases of the experiment
+	PhaseTimings []PhaseTiming
+	// ReportFormat contains the comma separated formats of the run report, e.g. json,junit,markdown
+	ReportFormat string
+	// ReportPath contains the directory of the run report
+	ReportPath string
+	// RunID is the unique id of the run, it is shared by the experiment and its helper pods
+	RunID string
+}
+
+// PhaseTiming contains the start time of the experiment phase
+type PhaseTiming struct {
+	Phase     ExperimentPhase
+	StartTime time.Time
 }
 
 type SideCar struct {
@@ -155,6 +191,37 @@ type ParentResource struct {
 type ProbeContext struct {
 	Ctx        context.Context
 	CancelFunc context.CancelFunc
+	// Halt records the halt probe which breached during chaos
+	Halt *ProbeHalt
+}
+
+// ProbeHalt contains the details of the halt probe which breached during chaos
+type ProbeHalt struct {
+	mutex     sync.Mutex
+	probeName string
+}
+
+// Record records the breaching halt probe
+// it returns false, if the chaos is already halted by any other probe
+func (h *ProbeHalt) Record(probeName string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.probeName != "" {
+		return false
+	}
+	h.probeName = probeName
+	return true
+}
+
+// ProbeName returns the name of the breaching halt probe
+// it returns empty string, if the chaos is not halted
+func (h *ProbeHalt) ProbeName() string {
+	if h == nil {
+		return ""
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.probeName
 }
 
 // AppDetails contains all the application related envs
@@ -197,6 +264,54 @@ func parse(val string) []string {
 	return strings.Split(val, ",")
 }
 
+// abortCtx is cancelled once the experiment is aborted
+var abortCtx, abortFunc = context.WithCancel(context.Background())
+
+// AbortContext returns the context which is cancelled once the experiment is aborted
+// it is used to stop the in-flight retries of the probes, the status checks and reverts are not stopped by the abort
+func AbortContext() context.Context {
+	return abortCtx
+}
+
+// Abort cancels the abort context
+// the channels registered via NotifyAbort receive the termination signal, so the chaoslib and abort watcher revert the chaos
+func Abort() {
+	abortFunc()
+}
+
+// NotifyAbort relays the termination signals and the Abort to the given channel
+// it is used by the chaoslib and abort watcher in place of the signal.Notify, to revert the chaos halted by the probes
+func NotifyAbort(abort chan os.Signal) {
+	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-abortCtx.Done()
+		select {
+		case abort <- syscall.SIGTERM:
+		default:
+		}
+	}()
+}
+
+// IsAborted checks whether the experiment is aborted or halted by the probes
+func IsAborted() bool {
+	return abortCtx.Err() != nil
+}
+
+// abortRecorded is closed once the abort watcher records the verdict of the aborted experiment
+var abortRecorded = make(chan struct{})
+var abortRecordedOnce sync.Once
+
+// MarkAbortRecorded is called by the abort watcher, once it records the verdict of the aborted experiment
+func MarkAbortRecorded() {
+	abortRecordedOnce.Do(func() { close(abortRecorded) })
+}
+
+// WaitForAbortRecord waits until the abort watcher records the verdict of the aborted experiment
+// the abort watcher is the only one to record the verdict, the failures of the aborted experiment are not recorded
+func WaitForAbortRecord() {
+	<-abortRecorded
+}
+
 // InitialiseChaosVariables initialise all the global variables
 func InitialiseChaosVariables(chaosDetails *ChaosDetails) {
 	targets := Getenv("TARGETS", "")
@@ -217,9 +332,45 @@ func InitialiseChaosVariables(chaosDetails *ChaosDetails) {
 	chaosDetails.ProbeImagePullPolicy = Getenv("LIB_IMAGE_PULL_POLICY", "Always")
 	chaosDetails.ParentsResources = []ParentResource{}
 	chaosDetails.Targets = []v1alpha1.TargetDetails{}
-	chaosDetails.Phase = PreChaosPhase
-	chaosDetails.ProbeContext.Ctx, chaosDetails.ProbeContext.CancelFunc = context.WithCancel(context.Background())
+	chaosDetails.ReportFormat = Getenv("REPORT_FORMAT", "json")
+	chaosDetails.ReportPath = Getenv("REPORT_PATH", "/tmp")
+	chaosDetails.RunID = Getenv("RUN_ID", stringutils.GetRunID())
+	log.SetField(log.ExperimentField, chaosDetails.ExperimentName)
+	log.SetField(log.EngineField, chaosDetails.EngineName)
+	log.SetField(log.ChaosUIDField, string(chaosDetails.ChaosUID))
+	log.SetField(log.RunIDField, chaosDetails.RunID)
+	SetChaosPhase(chaosDetails, PreChaosPhase)
+	chaosDetails.ProbeContext.Ctx, chaosDetails.ProbeContext.CancelFunc = context.WithCancel(AbortContext())
+	chaosDetails.ProbeContext.Halt = &ProbeHalt{}
 	chaosDetails.Labels = map[string]string{}
+	metrics.Initialise(chaosDetails.ExperimentName, chaosDetails.EngineName, chaosDetails.ChaosNamespace)
+	notify.Initialise(chaosDetails.ExperimentName, chaosDetails.EngineName, chaosDetails.ChaosNamespace, string(chaosDetails.ChaosUID), chaosDetails.RunID)
+	tracing.SetAttributes(
+		attribute.String("chaos.experiment", chaosDetails.ExperimentName),
+		attribute.String("chaos.engine", chaosDetails.EngineName),
+		attribute.String("chaos.instance_id", chaosDetails.InstanceID),
+		attribute.String("k8s.namespace.name", chaosDetails.ChaosNamespace),
+		attribute.String("k8s.pod.name", chaosDetails.ChaosPodName),
+	)
+}
+
+// SetChaosPhase sets the current phase of the experiment and records its start time
+func SetChaosPhase(chaosDetails *ChaosDetails, phase ExperimentPhase) {
+	chaosDetails.Phase = phase
+	chaosDetails.PhaseTimings = append(chaosDetails.PhaseTimings, PhaseTiming{Phase: phase, StartTime: time.Now()})
+	tracing.StartPhase(string(phase))
+	log.SetField(log.PhaseField, string(phase))
+}
+
+// GetPhaseStartTime returns the start time of the given phase of the experiment
+// it returns false, if the experiment is not yet entered in the given phase
+func GetPhaseStartTime(chaosDetails *ChaosDetails, phase ExperimentPhase) (time.Time, bool) {
+	for _, timing := range chaosDetails.PhaseTimings {
+		if timing.Phase == phase {
+			return timing.StartTime, true
+		}
+	}
+	return time.Time{}, false
 }
 
 // SetResultAttributes initialise all the chaos result ENV
@@ -243,6 +394,9 @@ func SetResultAttributes(resultDetails *ResultDetails, chaosDetails ChaosDetails
 func SetResultAfterCompletion(resultDetails *ResultDetails, verdict v1alpha1.ResultVerdict, phase v1alpha1.ResultPhase, failStep string, errorCode cerrors.ErrorType) {
 	resultDetails.Verdict = verdict
 	resultDetails.Phase = phase
+	if failStep != "" {
+		resultDetails.FailStep, resultDetails.ErrorCode = failStep, errorCode
+	}
 	if errorCode != cerrors.ErrorTypeHelperPodFailed && resultDetails.Phase == v1alpha1.ResultPhaseError {
 		resultDetails.ErrorOutput = &v1alpha1.ErrorOutput{
 			Reason:    failStep,
@@ -326,6 +480,11 @@ func GetValuesFromChaosEngine(chaosDetails *ChaosDetails, clients clients.Client
 			if err := InitializeProbesInChaosResultDetails(chaosresult, experiment.Spec.Probe); err != nil {
 				return stacktrace.Propagate(err, "could not initialize probe")
 			}
+			if len(experiment.Spec.Probe) != 0 {
+				if err := initializeProbeInputs(chaosDetails, clients, chaosresult); err != nil {
+					return stacktrace.Propagate(err, "could not initialize probe inputs")
+				}
+			}
 			InitializeSidecarDetails(chaosDetails, engine, experiment.Spec.Components.ENV)
 		}
 	}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyAbort(t *testing.T) {
+	assert.False(t, IsAborted())
+
+	abort := make(chan os.Signal, 1)
+	NotifyAbort(abort)
+
+	select {
+	case <-abort:
+		t.Fatal("abort is received before the Abort")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	Abort()
+	select {
+	case sig := <-abort:
+		assert.Equal(t, syscall.SIGTERM, sig)
+	case <-time.After(5 * time.Second):
+		t.Fatal("abort is not received after the Abort")
+	}
+	require.Error(t, AbortContext().Err())
+	assert.True(t, IsAborted())
+
+	// the failures wait until the abort watcher records the verdict
+	recorded := make(chan struct{})
+	go func() {
+		WaitForAbortRecord()
+		close(recorded)
+	}()
+	select {
+	case <-recorded:
+		t.Fatal("abort record is received before the MarkAbortRecorded")
+	case <-time.After(50 * time.Millisecond):
+	}
+	MarkAbortRecorded()
+	MarkAbortRecorded()
+	select {
+	case <-recorded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("abort record is not received after the MarkAbortRecorded")
+	}
+}
+
+func TestGetPhaseStartTime(t *testing.T) {
+	startTime := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	chaosDetails := &ChaosDetails{PhaseTimings: []PhaseTiming{
+		{Phase: PreChaosPhase, StartTime: startTime},
+		{Phase: ChaosInjectPhase, StartTime: startTime.Add(time.Minute)},
+		{Phase: ChaosInjectPhase, StartTime: startTime.Add(2 * time.Minute)},
+	}}
+
+	got, ok := GetPhaseStartTime(chaosDetails, ChaosInjectPhase)
+	assert.True(t, ok)
+	assert.Equal(t, startTime.Add(time.Minute), got)
+
+	_, ok = GetPhaseStartTime(chaosDetails, PostChaosPhase)
+	assert.False(t, ok)
+}
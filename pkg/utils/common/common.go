@@ -8,20 +8,21 @@ import (
 	"math/rand"
 	"os"
 	"os/exec"
-	"os/signal"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/figwood/litmus-go/pkg/clients"
 	"github.com/figwood/litmus-go/pkg/events"
 	"github.com/figwood/litmus-go/pkg/log"
 	"github.com/figwood/litmus-go/pkg/math"
+	"github.com/figwood/litmus-go/pkg/probe"
 	"github.com/figwood/litmus-go/pkg/result"
+	"github.com/figwood/litmus-go/pkg/tracing"
 	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	apiv1 "k8s.io/api/core/v1"
 )
 
@@ -76,22 +77,39 @@ func AbortWatcherWithoutExit(expname string, clients clients.ClientSets, resultD
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
+	types.NotifyAbort(signChan)
 
 	// waiting until the abort signal received
 	<-signChan
 
 	log.Info("[Chaos]: Chaos Experiment Abortion started because of terminated signal received")
+	// stopping the in-flight retries of the probes
+	types.Abort()
+	// the failures of the experiment are not recorded once it is aborted, until the verdict is recorded by the abort watcher
+	defer types.MarkAbortRecorded()
+	// cleanup the source pods and resources of the probes, as the postchaos probes are not executed after the abort
+	if err := probe.CleanupProbes(resultDetails, chaosDetails, clients); err != nil {
+		log.Errorf("[ABORT]: Failed to cleanup the probes, err: %v", err)
+	}
 	// updating the chaosresult after stopped
 	failStep := "Chaos injection stopped!"
 	types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep, cerrors.ErrorTypeExperimentAborted)
-	if err := result.ChaosResult(chaosDetails, clients, resultDetails, "EOT"); err != nil {
+	msg := expname + " experiment has been aborted"
+	// recording the breaching probe, if the chaos is halted by the halt probe
+	if probeName := chaosDetails.ProbeContext.Halt.ProbeName(); probeName != "" {
+		msg = fmt.Sprintf("%s experiment has been halted by %s probe", expname, probeName)
+		resultDetails.FailStep, resultDetails.ErrorCode = fmt.Sprintf("%s {probe: %s}", failStep, probeName), cerrors.ErrorTypeProbeHalted
+		resultDetails.ErrorOutput = &v1alpha1.ErrorOutput{
+			Reason:    resultDetails.FailStep,
+			ErrorCode: string(resultDetails.ErrorCode),
+		}
+	}
+	if err := result.RecordAfterAbort(chaosDetails, clients, resultDetails); err != nil {
 		log.Errorf("[ABORT]: Failed to update result, err: %v", err)
 	}
 	log.Info("[ABORT]: Updated chaosresult post stop")
 
 	// generating summary event in chaosengine
-	msg := expname + " experiment has been aborted"
 	types.SetEngineEventAttributes(eventsDetails, types.Summary, msg, "Warning", chaosDetails)
 	err := events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 	if err != nil {
@@ -147,6 +165,39 @@ func (envDetails *ENVDetails) SetEnvFromDownwardAPI(apiVersion string, fieldPath
 	return envDetails
 }
 
+// SetMetricsEnv sets the pushgateway envs in envDetails struct
+// the helper pods push their metrics to the same pushgateway as the experiment pod
+func (envDetails *ENVDetails) SetMetricsEnv() *ENVDetails {
+	return envDetails.SetEnv("PUSHGATEWAY_URL", os.Getenv("PUSHGATEWAY_URL")).
+		SetEnv("PUSHGATEWAY_JOB", os.Getenv("PUSHGATEWAY_JOB"))
+}
+
+// SetTraceEnv sets the trace context and otlp envs in envDetails struct
+// the spans of the helper pods are the part of the same trace as the experiment
+func (envDetails *ENVDetails) SetTraceEnv() *ENVDetails {
+	return envDetails.SetEnv("TRACEPARENT", tracing.TraceParent()).
+		SetEnv("OTEL_EXPORTER_OTLP_ENDPOINT", os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")).
+		SetEnv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")).
+		SetEnv("OTEL_EXPORTER_OTLP_HEADERS", os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")).
+		SetEnv("OTEL_SERVICE_NAME", os.Getenv("OTEL_SERVICE_NAME"))
+}
+
+// SetLogEnv sets the log format, log level and run id envs in envDetails struct
+// the log lines of the helper pods carry the same run id as the experiment
+func (envDetails *ENVDetails) SetLogEnv() *ENVDetails {
+	return envDetails.SetEnv("LOG_FORMAT", os.Getenv("LOG_FORMAT")).
+		SetEnv("LOG_LEVEL", os.Getenv("LOG_LEVEL")).
+		SetEnv("RUN_ID", log.Field(log.RunIDField))
+}
+
+// SetNotifyEnv sets the webhook notification envs in envDetails struct
+// the helper pods send the revert notifications to the same webhooks as the experiment
+func (envDetails *ENVDetails) SetNotifyEnv() *ENVDetails {
+	return envDetails.SetEnv("NOTIFY_WEBHOOK_URLS", os.Getenv("NOTIFY_WEBHOOK_URLS")).
+		SetEnv("NOTIFY_EVENTS", os.Getenv("NOTIFY_EVENTS")).
+		SetEnv("NOTIFY_TEMPLATE", os.Getenv("NOTIFY_TEMPLATE"))
+}
+
 // getEnvSource return the env source for the given apiVersion & fieldPath
 func getEnvSource(apiVersion string, fieldPath string) apiv1.EnvVarSource {
 	downwardENV := apiv1.EnvVarSource{
@@ -0,0 +1,179 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/figwood/litmus-go/pkg/clients"
+	"github.com/figwood/litmus-go/pkg/probe"
+	"github.com/figwood/litmus-go/pkg/result"
+	"github.com/figwood/litmus-go/pkg/types"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	chaosClient "github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned/typed/litmuschaos/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// fakeCluster serves the chaosengine, chaosresult, helper pods and events of the experiment
+type fakeCluster struct {
+	mutex          sync.Mutex
+	engine         v1alpha1.ChaosEngine
+	result         v1alpha1.ChaosResult
+	verdicts       []v1alpha1.ResultVerdict
+	deletedHelpers []string
+}
+
+func (c *fakeCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	encode := func(code int, object interface{}) {
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(object)
+	}
+	switch {
+	case r.URL.Path == "/apis/litmuschaos.io/v1alpha1/namespaces/litmus/chaosengines/nginx-chaos":
+		encode(http.StatusOK, c.engine)
+	case r.URL.Path == "/apis/litmuschaos.io/v1alpha1/namespaces/litmus/chaosresults/nginx-chaos-pod-network-loss" && r.Method == http.MethodGet:
+		encode(http.StatusOK, c.result)
+	case r.URL.Path == "/apis/litmuschaos.io/v1alpha1/namespaces/litmus/chaosresults/nginx-chaos-pod-network-loss" && r.Method == http.MethodPut:
+		_ = json.NewDecoder(r.Body).Decode(&c.result)
+		c.verdicts = append(c.verdicts, c.result.Status.ExperimentStatus.Verdict)
+		encode(http.StatusOK, c.result)
+	case r.URL.Path == "/api/v1/namespaces/litmus/pods" && r.Method == http.MethodGet:
+		encode(http.StatusOK, corev1.PodList{Items: []corev1.Pod{{ObjectMeta: v1.ObjectMeta{Name: "pod-network-loss-helper-hxwkt", Namespace: "litmus"}}}})
+	case strings.HasPrefix(r.URL.Path, "/api/v1/namespaces/litmus/pods/") && r.Method == http.MethodDelete:
+		c.deletedHelpers = append(c.deletedHelpers, strings.TrimPrefix(r.URL.Path, "/api/v1/namespaces/litmus/pods/"))
+		encode(http.StatusOK, v1.Status{Status: v1.StatusSuccess})
+	case strings.HasPrefix(r.URL.Path, "/api/v1/namespaces/litmus/pods/"):
+		encode(http.StatusOK, corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "pod-network-loss-abcde", Namespace: "litmus", Labels: map[string]string{"name": "pod-network-loss"}}})
+	case strings.HasPrefix(r.URL.Path, "/api/v1/namespaces/litmus/events/"):
+		notFound := k8serrors.NewNotFound(schema.GroupResource{Resource: "events"}, strings.TrimPrefix(r.URL.Path, "/api/v1/namespaces/litmus/events/"))
+		encode(http.StatusNotFound, notFound.ErrStatus)
+	case r.URL.Path == "/api/v1/namespaces/litmus/events" && r.Method == http.MethodPost:
+		event := corev1.Event{}
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		encode(http.StatusCreated, event)
+	default:
+		encode(http.StatusNotFound, v1.Status{Status: v1.StatusFailure, Code: http.StatusNotFound, Reason: v1.StatusReasonNotFound})
+	}
+}
+
+func TestAbortWatcherAfterHalt(t *testing.T) {
+	// the application under test is breaching the halt probe
+	application := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer application.Close()
+
+	haltProbe := v1alpha1.ProbeAttributes{
+		Name: "http-health",
+		Type: "httpProbe",
+		Mode: "OnChaos",
+		HTTPProbeInputs: &v1alpha1.HTTPProbeInputs{
+			URL:    application.URL,
+			Method: v1alpha1.HTTPMethod{Get: &v1alpha1.GetMethod{Criteria: "==", ResponseCode: "200"}},
+		},
+		RunProperties: v1alpha1.RunProperty{ProbeTimeout: "5s", Interval: "1s", Attempt: 1},
+	}
+	cluster := &fakeCluster{
+		engine: v1alpha1.ChaosEngine{
+			ObjectMeta: v1.ObjectMeta{Name: "nginx-chaos", Namespace: "litmus"},
+			Spec: v1alpha1.ChaosEngineSpec{Experiments: []v1alpha1.ExperimentList{{
+				Name: "pod-network-loss",
+				Spec: v1alpha1.ExperimentAttributes{Probe: []v1alpha1.ProbeAttributes{haltProbe}},
+			}}},
+		},
+		result: v1alpha1.ChaosResult{
+			ObjectMeta: v1.ObjectMeta{Name: "nginx-chaos-pod-network-loss", Namespace: "litmus"},
+			Status:     v1alpha1.ChaosResultStatus{ExperimentStatus: v1alpha1.TestStatus{Phase: v1alpha1.ResultPhaseRunning, Verdict: v1alpha1.ResultVerdictAwaited}},
+		},
+	}
+	server := httptest.NewServer(cluster)
+	defer server.Close()
+	clientSets := clients.ClientSets{
+		KubeClient:   kubernetes.NewForConfigOrDie(&rest.Config{Host: server.URL}),
+		LitmusClient: chaosClient.NewForConfigOrDie(&rest.Config{Host: server.URL}),
+	}
+
+	chaosDetails := &types.ChaosDetails{
+		ExperimentName: "pod-network-loss",
+		EngineName:     "nginx-chaos",
+		ChaosNamespace: "litmus",
+		ChaosPodName:   "pod-network-loss-abcde",
+		ChaosDuration:  60,
+		Timeout:        10,
+		Delay:          1,
+		Phase:          types.ChaosInjectPhase,
+		// the helper labels are set by the helper-based chaoslib, via GetHelperLabels
+		Labels: map[string]string{"app": "pod-network-loss-helper-abcde", "chaosUID": "1234"},
+	}
+	chaosDetails.ProbeContext.Ctx, chaosDetails.ProbeContext.CancelFunc = context.WithCancel(types.AbortContext())
+	chaosDetails.ProbeContext.Halt = &types.ProbeHalt{}
+	resultDetails := &types.ResultDetails{
+		Name:    "nginx-chaos-pod-network-loss",
+		Verdict: v1alpha1.ResultVerdictAwaited,
+		Phase:   v1alpha1.ResultPhaseRunning,
+		ProbeDetails: []*types.ProbeDetails{{
+			Name:     "http-health",
+			Type:     "httpProbe",
+			Mode:     "OnChaos",
+			Status:   v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictAwaited},
+			Timeouts: types.ProbeTimeouts{ProbeTimeout: 5 * time.Second, Interval: time.Second, ProbePollingInterval: time.Second},
+			Inputs:   types.ProbeInputs{Halt: true},
+		}},
+	}
+	eventsDetails := &types.EventDetails{}
+
+	watcherDone := make(chan struct{})
+	go func() {
+		AbortWatcherWithoutExit(chaosDetails.ExperimentName, clientSets, resultDetails, chaosDetails, eventsDetails)
+		close(watcherDone)
+	}()
+
+	// the helper-based chaoslib injects the chaos and evaluates the onchaos probes
+	require.NoError(t, probe.RunProbes(chaosDetails, clientSets, resultDetails, "DuringChaos", eventsDetails))
+	select {
+	case <-types.AbortContext().Done():
+	case <-time.After(30 * time.Second):
+		t.Fatal("experiment is not halted by the http-health probe")
+	}
+	// the helpers are deleted by the halt, so the chaoslib fails while waiting for the helpers
+	helperErr := cerrors.Error{ErrorCode: cerrors.ErrorTypeHelperPodFailed, Target: "{podName: pod-network-loss-helper-hxwkt, namespace: litmus}", Reason: "helper pod is not found"}
+	result.RecordAfterFailure(chaosDetails, resultDetails, helperErr, clientSets, eventsDetails)
+
+	// the failure is recorded only after the abort watcher records the verdict
+	select {
+	case <-watcherDone:
+	default:
+		t.Fatal("failure is recorded before the abort watcher")
+	}
+	assert.Equal(t, v1alpha1.ResultVerdictStopped, resultDetails.Verdict)
+	assert.Equal(t, v1alpha1.ResultPhaseStopped, resultDetails.Phase)
+	assert.Equal(t, cerrors.ErrorTypeProbeHalted, resultDetails.ErrorCode)
+	assert.Contains(t, resultDetails.FailStep, "{probe: http-health}")
+	assert.True(t, resultDetails.ProbeDetails[0].Stopped)
+
+	// the chaosresult is updated only once, by the abort watcher
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	assert.Equal(t, []v1alpha1.ResultVerdict{v1alpha1.ResultVerdictStopped}, cluster.verdicts)
+	assert.Equal(t, v1alpha1.ResultPhaseStopped, cluster.result.Status.ExperimentStatus.Phase)
+	require.NotNil(t, cluster.result.Status.ExperimentStatus.ErrorOutput)
+	assert.Equal(t, string(cerrors.ErrorTypeProbeHalted), cluster.result.Status.ExperimentStatus.ErrorOutput.ErrorCode)
+	assert.Equal(t, []string{"pod-network-loss-helper-hxwkt"}, cluster.deletedHelpers)
+}
@@ -17,6 +17,9 @@ import (
 	"github.com/figwood/litmus-go/pkg/clients"
 	"github.com/figwood/litmus-go/pkg/log"
 	"github.com/figwood/litmus-go/pkg/math"
+	"github.com/figwood/litmus-go/pkg/metrics"
+	"github.com/figwood/litmus-go/pkg/notify"
+	"github.com/figwood/litmus-go/pkg/tracing"
 	"github.com/figwood/litmus-go/pkg/types"
 	"github.com/figwood/litmus-go/pkg/utils/retry"
 	"github.com/figwood/litmus-go/pkg/workloads"
@@ -226,6 +229,9 @@ func GetTargetPodsWhenTargetPodsENVSet(targetPods, namespace string, clients cli
 
 // SetTargets set the target details in chaosdetails struct
 func SetTargets(target, chaosStatus, kind string, chaosDetails *types.ChaosDetails) {
+	metrics.RecordTargetStatus(target, kind, chaosStatus)
+	tracing.RecordTargetStatus(target, kind, chaosStatus)
+	notify.RecordTargetStatus(target, kind, chaosStatus)
 
 	for i := range chaosDetails.Targets {
 		if chaosDetails.Targets[i].Name == target {
@@ -608,6 +614,7 @@ type target struct {
 
 func ParseTargets(source string) (*TargetsDetails, error) {
 	var targets TargetsDetails
+	var targetPods []string
 	targetEnv := os.Getenv("TARGETS")
 	if targetEnv == "" {
 		return nil, cerrors.Error{ErrorCode: cerrors.ErrorTypeHelper, Source: source, Reason: "no target found, provide atleast one target"}
@@ -623,7 +630,9 @@ func ParseTargets(source string) (*TargetsDetails, error) {
 			Namespace:       targetList[1],
 			TargetContainer: targetList[2],
 		})
+		targetPods = append(targetPods, targetList[0])
 	}
+	log.SetField(log.TargetPodField, strings.Join(targetPods, ","))
 	return &targets, nil
 }
 
@@ -1,21 +1,65 @@
 package retry
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"github.com/figwood/litmus-go/pkg/cerrors"
+	"math/rand"
 	"time"
 
-	"github.com/pkg/errors"
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/palantir/stacktrace"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // Action defines the prototype of action function, function as a value
 type Action func(attempt uint) error
 
+// Classifier defines the prototype of the function which decides whether the error is retryable
+type Classifier func(err error) bool
+
 // Model defines the schema, contains all the attributes need for retry
 type Model struct {
-	retry    uint
-	waitTime time.Duration
-	timeout  time.Duration
+	retry      uint
+	waitTime   time.Duration
+	timeout    time.Duration
+	ctx        context.Context
+	factor     float64
+	maxWait    time.Duration
+	jitter     float64
+	maxElapsed time.Duration
+	retryIf    Classifier
+}
+
+// permanentError marks the error as non-retryable
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the error as non-retryable, it stops the retries immediately
+// the underlying error is returned by the Try and TryWithTimeout
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsRetryableAPIError returns false for the kubernetes api errors which are not resolved by the retries
+// e.g. invalid, badRequest, forbidden, unauthorized. All the other errors are retryable
+func IsRetryableAPIError(err error) bool {
+	err = stacktrace.RootCause(err)
+	return !(k8serrors.IsInvalid(err) || k8serrors.IsBadRequest(err) || k8serrors.IsForbidden(err) ||
+		k8serrors.IsUnauthorized(err) || k8serrors.IsMethodNotSupported(err) || k8serrors.IsNotAcceptable(err) ||
+		k8serrors.IsUnsupportedMediaType(err) || k8serrors.IsRequestEntityTooLargeError(err))
 }
 
 // Times is used to define the retry count
@@ -60,24 +104,58 @@ func (model *Model) Timeout(timeout time.Duration) *Model {
 	return model
 }
 
+// Context is used to define the context, the retries are stopped once the context is done
+// it will run if the instance of model is not present before
+func Context(ctx context.Context) *Model {
+	model := Model{}
+	return model.Context(ctx)
+}
+
+// Context is used to define the context, the retries are stopped once the context is done
+// it will run if the instance of model is already present
+func (model *Model) Context(ctx context.Context) *Model {
+	model.ctx = ctx
+	return model
+}
+
+// Backoff is used to define the exponential backoff, the wait duration is multiplied by the factor after each iteration
+// the wait duration doesn't exceed the maxWait, if provided
+// it will run if the instance of model is already present
+func (model *Model) Backoff(factor float64, maxWait time.Duration) *Model {
+	model.factor = factor
+	model.maxWait = maxWait
+	return model
+}
+
+// Jitter is used to randomize the wait duration by the given fraction, e.g. 0.2 for ±20%
+// it will run if the instance of model is already present
+func (model *Model) Jitter(fraction float64) *Model {
+	model.jitter = fraction
+	return model
+}
+
+// MaxElapsed is used to define the maximum duration of all the iterations of retry
+// it will run if the instance of model is already present
+func (model *Model) MaxElapsed(maxElapsed time.Duration) *Model {
+	model.maxElapsed = maxElapsed
+	return model
+}
+
+// RetryIf is used to define the classifier of the retryable errors
+// it stops the retries once the action returns the non-retryable error
+// it will run if the instance of model is already present
+func (model *Model) RetryIf(classifier Classifier) *Model {
+	model.retryIf = classifier
+	return model
+}
+
 // Try is used to run a action with retries and some delay after each iteration
+// it waits after the successful and the last iteration as well, the callers use it for pacing
 func (model Model) Try(action Action) error {
 	if action == nil {
 		return fmt.Errorf("no action specified")
 	}
-
-	var err error
-	for attempt := uint(0); (attempt == 0 || err != nil) && attempt < model.retry; attempt++ {
-		err = action(attempt)
-		if model.waitTime > 0 {
-			time.Sleep(model.waitTime)
-		}
-		if err == errors.Errorf("container is in terminated state") {
-			break
-		}
-	}
-
-	return err
+	return model.run(action, true)
 }
 
 // TryWithTimeout is used to run an action with retries
@@ -86,22 +164,114 @@ func (model Model) TryWithTimeout(action Action) error {
 	if action == nil {
 		return fmt.Errorf("no action specified")
 	}
-	var err error
-	err = nil
-	for attempt := uint(0); (attempt == 0 || err != nil) && attempt < model.retry; {
+	return model.run(func(attempt uint) error {
 		startTime := time.Now().UnixMilli()
-		err = action(attempt)
+		err := action(attempt)
 		if err == nil && time.Now().UnixMilli()-startTime >= model.timeout.Milliseconds() {
 			err = cerrors.Error{
 				ErrorCode: cerrors.ErrorTypeTimeout,
 				Reason:    "action timeout",
 			}
 		}
-		attempt++
-		if model.waitTime > 0 && attempt < model.retry {
-			time.Sleep(model.waitTime)
-		}
+		return err
+	}, false)
+}
+
+// run runs the action until it succeeds or the retries are exhausted
+// it waits after the successful and the last attempt as well, if paced
+// it returns the error of the last attempt
+func (model Model) run(action Action, paced bool) error {
+	ctx := model.ctx
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
+	var err error
+	startTime := time.Now()
+	waitTime := model.waitTime
+	for attempt := uint(0); attempt < model.retry; attempt++ {
+		if ctx.Err() != nil {
+			return model.stopped(ctx, err)
+		}
+		if err = action(attempt); err == nil {
+			if paced {
+				model.sleep(ctx, model.getWaitTime(waitTime, nil))
+			}
+			return nil
+		}
+
+		var permanent permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if model.retryIf != nil && !model.retryIf(err) {
+			return err
+		}
+
+		wait := model.getWaitTime(waitTime, err)
+		if attempt+1 == model.retry {
+			if paced {
+				model.sleep(ctx, wait)
+			}
+			break
+		}
+		if model.maxElapsed > 0 && time.Since(startTime)+wait > model.maxElapsed {
+			break
+		}
+		if !model.sleep(ctx, wait) {
+			return model.stopped(ctx, err)
+		}
+		waitTime = model.nextWaitTime(waitTime)
+	}
 	return err
 }
+
+// sleep waits for the given duration, it returns false if the context is done before that
+func (model Model) sleep(ctx context.Context, wait time.Duration) bool {
+	if wait <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// getWaitTime returns the wait duration before the next iteration
+// it adds the jitter and waits at least the delay suggested by the api server for the throttled requests
+func (model Model) getWaitTime(waitTime time.Duration, err error) time.Duration {
+	if model.jitter > 0 && waitTime > 0 {
+		waitTime += time.Duration((rand.Float64()*2 - 1) * model.jitter * float64(waitTime))
+	}
+	if seconds, ok := k8serrors.SuggestsClientDelay(stacktrace.RootCause(err)); ok {
+		if delay := time.Duration(seconds) * time.Second; delay > waitTime {
+			waitTime = delay
+		}
+	}
+	return waitTime
+}
+
+// nextWaitTime returns the wait duration of the next iteration, based on the backoff
+func (model Model) nextWaitTime(waitTime time.Duration) time.Duration {
+	if model.factor <= 1 {
+		return waitTime
+	}
+	waitTime = time.Duration(float64(waitTime) * model.factor)
+	if model.maxWait > 0 && waitTime > model.maxWait {
+		waitTime = model.maxWait
+	}
+	return waitTime
+}
+
+// stopped returns the error of the last attempt, once the context is done
+// it returns the context error, if the action is not attempted
+func (model Model) stopped(ctx context.Context, err error) error {
+	if err != nil {
+		return err
+	}
+	return cerrors.Error{ErrorCode: cerrors.ErrorTypeTimeout, Reason: fmt.Sprintf("retries are stopped: %v", ctx.Err())}
+}
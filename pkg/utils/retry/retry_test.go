@@ -0,0 +1,192 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/figwood/litmus-go/pkg/cerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var errFailed = errors.New("failed")
+
+func TestNextWaitTime(t *testing.T) {
+	tests := []struct {
+		name  string
+		model *Model
+		want  []time.Duration
+	}{
+		{name: "no backoff", model: Wait(time.Second), want: []time.Duration{time.Second, time.Second, time.Second}},
+		{name: "factor of one", model: Wait(time.Second).Backoff(1, 0), want: []time.Duration{time.Second, time.Second, time.Second}},
+		{name: "exponential growth", model: Wait(time.Second).Backoff(2, 0), want: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}},
+		{name: "fractional factor", model: Wait(time.Second).Backoff(1.5, 0), want: []time.Duration{time.Second, 1500 * time.Millisecond, 2250 * time.Millisecond}},
+		{name: "capped by max wait", model: Wait(time.Second).Backoff(2, 5*time.Second), want: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waitTime := tt.model.waitTime
+			var got []time.Duration
+			for range tt.want {
+				got = append(got, waitTime)
+				waitTime = tt.model.nextWaitTime(waitTime)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetWaitTimeJitter(t *testing.T) {
+	model := Wait(time.Second).Jitter(0.2)
+	distinct := map[time.Duration]bool{}
+	for i := 0; i < 1000; i++ {
+		wait := model.getWaitTime(time.Second, errFailed)
+		require.GreaterOrEqual(t, wait, 800*time.Millisecond)
+		require.LessOrEqual(t, wait, 1200*time.Millisecond)
+		distinct[wait] = true
+	}
+	assert.Greater(t, len(distinct), 1, "jitter should randomize the wait duration")
+
+	// without jitter, the wait duration is unchanged
+	assert.Equal(t, time.Second, Wait(time.Second).getWaitTime(time.Second, errFailed))
+	// the delay suggested by the api server is honoured
+	assert.Equal(t, 3*time.Second, model.getWaitTime(time.Second, k8serrors.NewTooManyRequests("throttled", 3)))
+}
+
+func TestRunBackoff(t *testing.T) {
+	var attempts []time.Time
+	err := Times(4).Wait(10*time.Millisecond).Backoff(2, 25*time.Millisecond).Try(func(attempt uint) error {
+		attempts = append(attempts, time.Now())
+		return errFailed
+	})
+	assert.Equal(t, errFailed, err)
+	require.Len(t, attempts, 4)
+
+	// the waits are 10ms, 20ms and then capped to 25ms
+	for i, want := range []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 25 * time.Millisecond} {
+		assert.GreaterOrEqual(t, attempts[i+1].Sub(attempts[i]), want, "wait after attempt %d", i)
+	}
+}
+
+func TestRunSucceeds(t *testing.T) {
+	var count uint
+	err := Times(5).Wait(time.Millisecond).Try(func(attempt uint) error {
+		assert.Equal(t, count, attempt)
+		count++
+		if attempt < 2 {
+			return errFailed
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), count)
+}
+
+func TestTryPacing(t *testing.T) {
+	tests := []struct {
+		name     string
+		try      func(model *Model, action Action) error
+		failures uint
+		minimum  time.Duration
+		maximum  time.Duration
+	}{
+		// the try waits after each iteration, including the successful and the last one
+		{name: "try succeeds", try: (*Model).Try, failures: 1, minimum: 100 * time.Millisecond},
+		{name: "try exhausted", try: (*Model).Try, failures: 2, minimum: 100 * time.Millisecond},
+		// the try with timeout only waits between the iterations
+		{name: "try with timeout succeeds", try: (*Model).TryWithTimeout, failures: 1, minimum: 50 * time.Millisecond, maximum: 100 * time.Millisecond},
+		{name: "try with timeout exhausted", try: (*Model).TryWithTimeout, failures: 2, minimum: 50 * time.Millisecond, maximum: 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startTime := time.Now()
+			_ = tt.try(Times(2).Wait(50*time.Millisecond).Timeout(time.Second), func(attempt uint) error {
+				if attempt < tt.failures {
+					return errFailed
+				}
+				return nil
+			})
+			elapsed := time.Since(startTime)
+			assert.GreaterOrEqual(t, elapsed, tt.minimum)
+			if tt.maximum > 0 {
+				assert.Less(t, elapsed, tt.maximum)
+			}
+		})
+	}
+}
+
+func TestRunMaxElapsed(t *testing.T) {
+	count := 0
+	startTime := time.Now()
+	err := Times(100).Wait(100 * time.Millisecond).MaxElapsed(150 * time.Millisecond).Try(func(attempt uint) error {
+		count++
+		return errFailed
+	})
+	assert.Equal(t, errFailed, err)
+	// the second wait would cross the max elapsed duration, so it stops without waiting
+	assert.Equal(t, 2, count)
+	assert.Less(t, time.Since(startTime), 150*time.Millisecond)
+}
+
+func TestRunPermanent(t *testing.T) {
+	count := 0
+	err := Times(5).Wait(time.Millisecond).Try(func(attempt uint) error {
+		count++
+		return Permanent(errFailed)
+	})
+	assert.Equal(t, errFailed, err)
+	assert.Equal(t, 1, count)
+	assert.Nil(t, Permanent(nil))
+}
+
+func TestRunRetryIf(t *testing.T) {
+	count := 0
+	err := Times(5).Wait(time.Millisecond).RetryIf(IsRetryableAPIError).Try(func(attempt uint) error {
+		count++
+		if attempt == 0 {
+			return k8serrors.NewServiceUnavailable("unavailable")
+		}
+		return k8serrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "nginx", errFailed)
+	})
+	assert.True(t, k8serrors.IsForbidden(err))
+	assert.Equal(t, 2, count)
+}
+
+func TestRunContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	count := 0
+	err := Times(5).Wait(time.Millisecond).Context(ctx).Try(func(attempt uint) error {
+		count++
+		return nil
+	})
+	require.Error(t, err)
+	assert.Equal(t, cerrors.ErrorTypeTimeout, cerrors.GetErrorType(err))
+	assert.Equal(t, 0, count)
+}
+
+func TestRunContextCancelledWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	count := 0
+	startTime := time.Now()
+	err := Times(5).Wait(time.Minute).Context(ctx).Try(func(attempt uint) error {
+		count++
+		return errFailed
+	})
+	// the error of the last attempt is returned without waiting for the full duration
+	assert.Equal(t, errFailed, err)
+	assert.Equal(t, 1, count)
+	assert.Less(t, time.Since(startTime), 10*time.Second)
+}
+
+func TestTryWithoutAction(t *testing.T) {
+	assert.Error(t, Times(1).Try(nil))
+	assert.Error(t, Times(1).TryWithTimeout(nil))
+}